@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"rosetta-ethereum-2.0/configuration"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the rosetta-ethereum-2.0 configuration",
+	}
+
+	configPrintCmd = &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration with secrets redacted",
+		RunE:  runConfigPrintCmd,
+	}
+)
+
+func runConfigPrintCmd(cmd *cobra.Command, args []string) error {
+	settings, _, err := configuration.LoadSettings(configFile, flagOverrides(cmd))
+	if err != nil {
+		return fmt.Errorf("%w: unable to load configuration", err)
+	}
+
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(settings.Redacted()); err != nil {
+		return fmt.Errorf("%w: unable to print configuration", err)
+	}
+
+	// Problems are reported after printing so the
+	// offending values can be inspected.
+	if _, err := configuration.LoadConfiguration(configFile, flagOverrides(cmd)); err != nil {
+		return fmt.Errorf("%w: configuration is invalid", err)
+	}
+
+	return nil
+}
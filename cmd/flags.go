@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"rosetta-ethereum-2.0/configuration"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	configFlag            = "config"
+	modeFlag              = "mode"
+	networkFlag           = "network"
+	portFlag              = "port"
+	beaconRPCFlag         = "beacon-rpc"
+	web3ProviderFlag      = "web3-provider"
+	tracesExporterFlag    = "traces-exporter"
+	prysmBinaryFlag       = "prysm-binary"
+	checkpointSyncURLFlag = "checkpoint-sync-url"
+	prysmExtraArgFlag     = "prysm-extra-arg"
+	readyMaxSlotLagFlag   = "ready-max-slot-lag"
+	readyMinPeersFlag     = "ready-min-peers"
+
+	participationMetadataFlag = "participation-metadata"
+)
+
+var (
+	configFile    string
+	flagSettings  = &configuration.Settings{Prysm: &configuration.PrysmSettings{}}
+	flagMaxLag    int64
+	flagMinPeers  int
+	flagMetadata  bool
+	flagExtraArgs []string
+)
+
+// addConfigurationFlags registers the flags that override the
+// configuration file and ENVs on every command.
+func addConfigurationFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.StringVar(
+		&configFile,
+		configFlag,
+		"",
+		"YAML or JSON configuration file (overrides "+configuration.ConfigFileEnv+")",
+	)
+	flags.StringVar(&flagSettings.Mode, modeFlag, "", "ONLINE or OFFLINE")
+	flags.StringVar(&flagSettings.Network, networkFlag, "", "comma-separated networks to serve: MAINNET, TESTNET or custom network names")
+	flags.IntVar(&flagSettings.Port, portFlag, 0, "port to serve the Rosetta API on")
+	flags.StringVar(&flagSettings.BeaconRPC, beaconRPCFlag, "", "gRPC address of an already running beacon node, one comma-separated entry per network (empty for the local prysm)")
+	flags.StringSliceVar(
+		&flagSettings.Web3Providers,
+		web3ProviderFlag,
+		nil,
+		"execution-layer endpoint used by prysm, repeat for fallbacks",
+	)
+	flags.StringVar(&flagSettings.TracesExporter, tracesExporterFlag, "", "none or otlp")
+	flags.StringVar(&flagSettings.Prysm.Binary, prysmBinaryFlag, "", "location of the prysm beacon-chain binary")
+	flags.StringVar(
+		&flagSettings.Prysm.CheckpointSyncURL,
+		checkpointSyncURLFlag,
+		"",
+		"beacon node API to checkpoint sync prysm from",
+	)
+	flags.StringArrayVar(&flagExtraArgs, prysmExtraArgFlag, nil, "argument appended to the prysm command line, repeatable")
+	flags.Int64Var(&flagMaxLag, readyMaxSlotLagFlag, 0, "slots the beacon node may lag while still ready")
+	flags.IntVar(&flagMinPeers, readyMinPeersFlag, 0, "peers required for readiness")
+	flags.BoolVar(
+		&flagMetadata,
+		participationMetadataFlag,
+		false,
+		"add the validator participation of the previous epoch to the first block of every epoch",
+	)
+}
+
+// flagOverrides returns the Settings populated
+// by flags explicitly set on cmd.
+func flagOverrides(cmd *cobra.Command) *configuration.Settings {
+	overrides := flagSettings.Merge(nil)
+
+	flags := cmd.Flags()
+	if flags.Changed(prysmExtraArgFlag) {
+		overrides.Prysm.ExtraArgs = flagExtraArgs
+	}
+	if flags.Changed(readyMaxSlotLagFlag) {
+		overrides.Readiness.MaxSlotLag = &flagMaxLag
+	}
+	if flags.Changed(readyMinPeersFlag) {
+		overrides.Readiness.MinPeers = &flagMinPeers
+	}
+	if flags.Changed(participationMetadataFlag) {
+		overrides.ParticipationMetadata = &flagMetadata
+	}
+
+	return overrides
+}
@@ -28,7 +28,11 @@ func Execute() error {
 }
 
 func init() {
+	addConfigurationFlags(rootCmd)
+
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configPrintCmd)
 }
 
 // handleSignals handles OS signals so we can ensure we close database
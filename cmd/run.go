@@ -11,10 +11,10 @@ import (
 	"rosetta-ethereum-2.0/configuration"
 	"rosetta-ethereum-2.0/ethereum"
 	"rosetta-ethereum-2.0/services"
+	"rosetta-ethereum-2.0/tracing"
 
 	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/server"
-	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 )
@@ -43,8 +43,7 @@ var (
 )
 
 func runRunCmd(cmd *cobra.Command, args []string) error {
-	fmt.Printf("Unix epoch: %s", asserter.MinUnixEpoch)
-	cfg, err := configuration.LoadConfiguration()
+	cfg, err := configuration.LoadConfiguration(configFile, flagOverrides(cmd))
 	if err != nil {
 		return fmt.Errorf("%w: unable to load configuration", err)
 	}
@@ -54,9 +53,7 @@ func runRunCmd(cmd *cobra.Command, args []string) error {
 	asserter, err := asserter.NewServer(
 		ethereum.OperationTypes,
 		ethereum.HistoricalBalanceSupported,
-		[]*types.NetworkIdentifier{
-			cfg.Network,
-		},
+		cfg.NetworkIdentifiers(),
 		ethereum.CallMethods,
 		false,
 	)
@@ -69,31 +66,74 @@ func runRunCmd(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	go handleSignals([]context.CancelFunc{cancel})
 
+	shutdownTracing, err := tracing.Start(ctx, cfg.TracesExporter)
+	if err != nil {
+		return fmt.Errorf("%w: unable to start tracing", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("unable to flush traces: %s", err)
+		}
+	}()
+
 	g, ctx := errgroup.WithContext(ctx)
 
-	var client *ethereum.Client
+	clients := services.Clients{}
+	var supervisor services.ProcessSupervisor
 	if cfg.Mode == configuration.Online {
-		if !cfg.RemoteBeacon {
+		if cfg.LocalNetwork() != nil {
+			prysm := ethereum.NewPrysmSupervisor(
+				cfg.Prysm.Binary,
+				cfg.Prysm.Arguments(),
+			)
+			supervisor = prysm
 			g.Go(func() error {
-				return ethereum.StartPrysm(ctx, cfg.PrysmArguments, g)
+				return prysm.Run(ctx)
 			})
 		}
 
-		var err error
-		client, err = ethereum.NewClient(ctx, cfg.BeaconURL)
-		if err != nil {
-			return fmt.Errorf("%w: cannot initialize ethereum client", err)
+		for _, network := range cfg.Networks {
+			client, err := ethereum.NewClient(
+				ctx,
+				network.BeaconURL,
+				network.Web3Provider,
+				network.Config,
+			)
+			if err != nil {
+				return fmt.Errorf(
+					"%w: cannot initialize ethereum client for %s",
+					err,
+					network.Identifier.Network,
+				)
+			}
+			defer client.Close()
+
+			if cfg.ParticipationMetadata {
+				client.EnableParticipationMetadata()
+			}
+			client.WarmValidatorRegistry()
+
+			clients[network.Identifier.Network] = client
 		}
-		defer client.Close()
 	}
 
-	router := services.NewBlockchainRouter(cfg, client, asserter)
+	router := services.NewBlockchainRouter(cfg, clients, supervisor, asserter)
 
 	loggedRouter := server.LoggerMiddleware(router)
 	corsRouter := server.CorsMiddleware(loggedRouter)
+
+	// Probes are served outside of the logger so that
+	// orchestration polling does not flood the logs.
+	healthRouter := services.NewHealthRouter(cfg, clients, supervisor)
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", healthRouter)
+	mux.Handle("/readyz", healthRouter)
+	mux.Handle("/metrics", healthRouter)
+	mux.Handle("/", corsRouter)
+
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Port),
-		Handler:      corsRouter,
+		Handler:      mux,
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
 		IdleTimeout:  idleTimeout,
@@ -4,9 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"strings"
 
 	"rosetta-ethereum-2.0/ethereum"
+	"rosetta-ethereum-2.0/tracing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
@@ -39,7 +40,9 @@ const (
 	ModeEnv = "MODE"
 
 	// NetworkEnv is the environment variable
-	// read to determine network.
+	// read to determine network. Multiple comma-separated
+	// networks may be provided to serve all of them from
+	// a single process.
 	NetworkEnv = "NETWORK"
 
 	// PortEnv is the environment variable
@@ -49,19 +52,79 @@ const (
 
 	// BeaconRPCEnv is an optional environment variable
 	// used to connect rosetta-ethereum to an already
-	// running beacon node.
+	// running beacon node. When several networks are
+	// served, it holds one comma-separated entry per
+	// network in NetworkEnv, where an empty entry selects
+	// the local prysm.
 	BeaconRPCEnv = "BEACON_RPC"
 
 	// HTTPWeb3ProviderEnv is the environment variable
 	// used to connect beacon-node to an already synced
-	// ethereum node
+	// ethereum node. Multiple comma-separated URLs may be
+	// provided, in which case all but the first are used
+	// as fallbacks.
 	HTTPWeb3ProviderEnv = "WEB3PROVIDER"
 
+	// PrysmBinaryEnv is an optional environment variable
+	// used to override the location of the prysm binary.
+	PrysmBinaryEnv = "PRYSM_BINARY"
+
+	// CheckpointSyncURLEnv is an optional environment variable
+	// used to sync prysm from a finalized checkpoint served
+	// by another beacon node.
+	CheckpointSyncURLEnv = "CHECKPOINT_SYNC_URL"
+
+	// PrysmExtraArgsEnv is an optional environment variable
+	// holding whitespace-separated flags appended to the
+	// prysm command line.
+	PrysmExtraArgsEnv = "PRYSM_EXTRA_ARGS"
+
 	// DefaultHTTPWeb3Provider is the default URL
 	// of already synced ethereum node used for connect
 	// beacon-node to connect to
 	DefaultHTTPWeb3Provider = "http://localhost:8545"
 
+	// ReadyMaxSlotLagEnv is an optional environment variable
+	// used to set how many slots the beacon node may lag behind
+	// the wall-clock slot while still being considered ready.
+	ReadyMaxSlotLagEnv = "READY_MAX_SLOT_LAG"
+
+	// ReadyMinPeersEnv is an optional environment variable
+	// used to set the minimum number of peers the beacon node
+	// must have to be considered ready.
+	ReadyMinPeersEnv = "READY_MIN_PEERS"
+
+	// TracesExporterEnv is an optional environment variable
+	// used to select where trace spans are exported. It
+	// accepts "none" (the default) or "otlp".
+	TracesExporterEnv = "OTEL_TRACES_EXPORTER"
+
+	// ParticipationMetadataEnv is an optional environment
+	// variable used to add the validator participation of
+	// the previous epoch to the first block of every epoch.
+	ParticipationMetadataEnv = "PARTICIPATION_METADATA"
+
+	// ConfigFileEnv is an optional environment variable
+	// pointing at a YAML or JSON configuration file.
+	ConfigFileEnv = "CONFIG_FILE"
+
+	// DefaultPrysmBinary is the default location
+	// of the prysm binary.
+	DefaultPrysmBinary = ethereum.PrysmBinary
+
+	// DefaultTracesExporter is the default trace
+	// exporter, which discards all spans.
+	DefaultTracesExporter = tracing.NoneExporter
+
+	// DefaultReadyMaxSlotLag is the default number of slots
+	// (two epochs) the beacon node may lag behind while still
+	// being considered ready.
+	DefaultReadyMaxSlotLag = 64
+
+	// DefaultReadyMinPeers is the default minimum number
+	// of peers required for readiness.
+	DefaultReadyMinPeers = 0
+
 	// DefaultRPCURL is the default URL for
 	// a running beacon node. This is used
 	// when BeaconRPCEnv is not populated.
@@ -73,75 +136,400 @@ const (
 
 // Configuration determines how
 type Configuration struct {
-	Mode                   Mode
-	Network                *types.NetworkIdentifier
+	Mode           Mode
+	Networks       []*Network
+	Port           int
+	Prysm          *ethereum.PrysmConfig
+	Readiness      *Readiness
+	TracesExporter string
+
+	// ParticipationMetadata adds the validator participation
+	// of the previous epoch to the first block of every epoch.
+	ParticipationMetadata bool
+}
+
+// Network is a network served by the
+// implementation and the beacon node backing it.
+type Network struct {
+	Identifier             *types.NetworkIdentifier
+	Config                 *ethereum.NetworkConfig
 	GenesisBlockIdentifier *types.BlockIdentifier
 	BeaconURL              string
 	RemoteBeacon           bool
-	Port                   int
-	PrysmArguments         string
+
+	// Web3Provider is the execution layer endpoint deposits
+	// are submitted to. Only the network served by the
+	// local prysm has one: the first of WEB3PROVIDER.
+	Web3Provider string
+}
+
+// NetworkIdentifiers returns the *types.NetworkIdentifier
+// of every served network.
+func (c *Configuration) NetworkIdentifiers() []*types.NetworkIdentifier {
+	identifiers := make([]*types.NetworkIdentifier, len(c.Networks))
+	for i, network := range c.Networks {
+		identifiers[i] = network.Identifier
+	}
+
+	return identifiers
+}
+
+// LocalNetwork returns the network served by the
+// local prysm, or nil if every network is served
+// by a remote beacon node.
+func (c *Configuration) LocalNetwork() *Network {
+	for _, network := range c.Networks {
+		if !network.RemoteBeacon {
+			return network
+		}
+	}
+
+	return nil
+}
+
+// Readiness determines when the implementation
+// reports itself as ready to serve requests.
+type Readiness struct {
+	MaxSlotLag int64
+	MinPeers   int
+}
+
+// ValidationError lists every problem
+// found while validating a configuration.
+type ValidationError struct {
+	Problems []error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		problems[i] = problem.Error()
+	}
+
+	return fmt.Sprintf(
+		"%d configuration problem(s): %s",
+		len(problems),
+		strings.Join(problems, "; "),
+	)
+}
+
+// Is reports whether any problem matches target.
+func (e *ValidationError) Is(target error) bool {
+	for _, problem := range e.Problems {
+		if errors.Is(problem, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadSettings layers the configuration file at path (if
+// populated), the ENVs in the environment and the provided
+// flags on top of the defaults, in increasing order of
+// precedence. problems lists the ENVs that could not be
+// parsed, while err is only populated when the configuration
+// file cannot be read.
+func LoadSettings(path string, flags *Settings) (*Settings, []error, error) {
+	settings := DefaultSettings()
+	if len(path) == 0 {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if len(path) > 0 {
+		fileSettings, err := SettingsFromFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		settings = settings.Merge(fileSettings)
+	}
+
+	envSettings, problems := SettingsFromEnv()
+
+	return settings.Merge(envSettings).Merge(flags), problems, nil
 }
 
 // LoadConfiguration attempts to create a new Configuration
-// using the ENVs in the environment.
-func LoadConfiguration() (*Configuration, error) {
+// from the configuration file at path, the ENVs in the
+// environment and the provided flags. See LoadSettings
+// for the precedence rules.
+func LoadConfiguration(path string, flags *Settings) (*Configuration, error) {
+	settings, problems, err := LoadSettings(path, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := NewConfiguration(settings)
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		problems = append(problems, validationErr.Problems...)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return config, nil
+}
+
+// NewConfiguration validates settings and converts them into a
+// Configuration. All problems are reported in a single
+// *ValidationError.
+func NewConfiguration(settings *Settings) (*Configuration, error) {
+	settings = DefaultSettings().Merge(settings)
 	config := &Configuration{}
+	problems := []error{}
 
-	modeValue := Mode(os.Getenv(ModeEnv))
-	switch modeValue {
-	case Online:
-		config.Mode = Online
-	case Offline:
-		config.Mode = Offline
+	switch Mode(settings.Mode) {
+	case Online, Offline:
+		config.Mode = Mode(settings.Mode)
 	case "":
-		return nil, errors.New("MODE must be populated")
+		problems = append(problems, errors.New("MODE must be populated"))
 	default:
-		return nil, fmt.Errorf("%s is not a valid mode", modeValue)
+		problems = append(problems, fmt.Errorf("%s is not a valid mode", settings.Mode))
 	}
 
-	httpWeb3Provider := DefaultHTTPWeb3Provider
-	envWeb3Provider := os.Getenv(HTTPWeb3ProviderEnv)
-	if len(envWeb3Provider) > 0 {
-		httpWeb3Provider = envWeb3Provider
+	config.Prysm = &ethereum.PrysmConfig{
+		Binary:            settings.Prysm.Binary,
+		ConfigFile:        ethereum.PrysmConfigFile,
+		DataDir:           DataDirectory,
+		Web3Providers:     settings.Web3Providers,
+		CheckpointSyncURL: settings.Prysm.CheckpointSyncURL,
+		ExtraArgs:         settings.Prysm.ExtraArgs,
 	}
 
-	networkValue := os.Getenv(NetworkEnv)
-	switch networkValue {
-	case Mainnet:
-		config.Network = &types.NetworkIdentifier{
-			Blockchain: ethereum.Blockchain,
-			Network:    ethereum.MainnetNetwork,
-		}
-		config.PrysmArguments = ethereum.MainnetPrysmArguments + " --http-web3provider=" + httpWeb3Provider
-	case Testnet:
-		config.Network = &types.NetworkIdentifier{
-			Blockchain: ethereum.Blockchain,
-			Network:    ethereum.TestnetNetwork,
+	networks, networkProblems := servedNetworks(settings)
+	problems = append(problems, networkProblems...)
+	config.Networks = networks
+
+	if local := config.LocalNetwork(); local != nil {
+		config.Prysm.NetworkFlags = local.Config.PrysmArguments()
+		if config.Mode == Online {
+			if err := config.Prysm.Validate(); err != nil {
+				problems = append(problems, fmt.Errorf("%w: invalid prysm configuration", err))
+			}
 		}
-		config.PrysmArguments = ethereum.TestnetPrysmArguments + "--http-web3provider=" + httpWeb3Provider
-	case "":
-		return nil, errors.New("NETWORK must be populated")
+	}
+
+	switch {
+	case settings.Port == 0:
+		problems = append(problems, errors.New("PORT must be populated"))
+	case settings.Port < 0:
+		problems = append(problems, fmt.Errorf("%d is not a valid port", settings.Port))
 	default:
-		return nil, fmt.Errorf("%s is not a valid network", networkValue)
+		config.Port = settings.Port
+	}
+
+	config.Readiness = &Readiness{
+		MaxSlotLag: *settings.Readiness.MaxSlotLag,
+		MinPeers:   *settings.Readiness.MinPeers,
+	}
+	if config.Readiness.MaxSlotLag < 0 {
+		problems = append(problems, fmt.Errorf("%d is not a valid readiness max slot lag", config.Readiness.MaxSlotLag))
+	}
+	if config.Readiness.MinPeers < 0 {
+		problems = append(problems, fmt.Errorf("%d is not a valid readiness min peers", config.Readiness.MinPeers))
 	}
 
-	config.BeaconURL = DefaultRPCURL
-	envBeaconRPC := os.Getenv(BeaconRPCEnv)
-	if len(envBeaconRPC) > 0 {
-		config.RemoteBeacon = true
-		config.BeaconURL = envBeaconRPC
+	if settings.ParticipationMetadata != nil {
+		config.ParticipationMetadata = *settings.ParticipationMetadata
 	}
 
-	portValue := os.Getenv(PortEnv)
-	if len(portValue) == 0 {
-		return nil, errors.New("PORT must be populated")
+	switch settings.TracesExporter {
+	case tracing.NoneExporter, tracing.OTLPExporter:
+		config.TracesExporter = settings.TracesExporter
+	default:
+		problems = append(problems, fmt.Errorf("%s is not a valid trace exporter", settings.TracesExporter))
 	}
 
-	port, err := strconv.Atoi(portValue)
-	if err != nil || len(portValue) == 0 || port <= 0 {
-		return nil, fmt.Errorf("%w: unable to parse port %s", err, portValue)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
 	}
-	config.Port = port
 
 	return config, nil
 }
+
+// servedNetworks returns the networks selected in settings,
+// each paired with the beacon node at the same position in
+// the BeaconRPC list. At most one network may be served by
+// the local prysm.
+func servedNetworks(settings *Settings) ([]*Network, []error) {
+	configs, problems := networkConfigs(settings.Networks)
+
+	names := SplitList(settings.Network)
+	if len(names) == 0 {
+		return nil, append(problems, errors.New("NETWORK must be populated"))
+	}
+
+	beacons := make([]string, len(names))
+	if len(settings.BeaconRPC) > 0 {
+		beacons = strings.Split(settings.BeaconRPC, ",")
+	}
+	if len(beacons) != len(names) {
+		return nil, append(problems, fmt.Errorf(
+			"BEACON_RPC has %d entries but %d networks are served",
+			len(beacons),
+			len(names),
+		))
+	}
+
+	networks := []*Network{}
+	served := map[string]bool{}
+	local := 0
+	for i, name := range names {
+		networkConfig, ok := configs[strings.ToUpper(name)]
+		if !ok {
+			problems = append(problems, fmt.Errorf("%s is not a valid network", name))
+			continue
+		}
+
+		if served[networkConfig.Name] {
+			problems = append(problems, fmt.Errorf("network %s is served more than once", name))
+			continue
+		}
+		served[networkConfig.Name] = true
+
+		network := &Network{
+			Identifier: &types.NetworkIdentifier{
+				Blockchain: ethereum.Blockchain,
+				Network:    networkConfig.Name,
+			},
+			Config:                 networkConfig,
+			GenesisBlockIdentifier: networkConfig.GenesisBlockIdentifier,
+			BeaconURL:              DefaultRPCURL,
+		}
+		if beacon := strings.TrimSpace(beacons[i]); len(beacon) > 0 {
+			network.RemoteBeacon = true
+			network.BeaconURL = beacon
+		} else {
+			local++
+			if len(settings.Web3Providers) > 0 {
+				network.Web3Provider = settings.Web3Providers[0]
+			}
+		}
+
+		networks = append(networks, network)
+	}
+
+	if local > 1 {
+		problems = append(problems, fmt.Errorf(
+			"%d networks use the local prysm but only one can",
+			local,
+		))
+	}
+
+	return networks, problems
+}
+
+// networkConfigs returns the built-in networks and the custom
+// networks in settings, keyed by their upper-case name.
+func networkConfigs(settings []*NetworkSettings) (map[string]*ethereum.NetworkConfig, []error) {
+	networks := map[string]*ethereum.NetworkConfig{
+		Mainnet: ethereum.MainnetNetworkConfig,
+		Testnet: ethereum.TestnetNetworkConfig,
+	}
+	problems := []error{}
+
+	for _, network := range settings {
+		name := strings.ToUpper(network.Name)
+		if _, ok := networks[name]; ok {
+			problems = append(problems, fmt.Errorf("network %s is defined more than once", network.Name))
+			continue
+		}
+
+		presetName := network.Preset
+		if len(presetName) == 0 {
+			presetName = ethereum.MainnetPreset
+		}
+		preset, ok := ethereum.Presets[presetName]
+		if !ok {
+			problems = append(problems, fmt.Errorf(
+				"%w: %s is not a valid preset for network %s",
+				ethereum.ErrPresetInvalid,
+				network.Preset,
+				network.Name,
+			))
+			continue
+		}
+
+		// Custom networks cannot be looked up anywhere
+		// else, so their genesis validators root is
+		// required to sign operations.
+		if len(network.GenesisValidatorsRoot) == 0 {
+			problems = append(problems, fmt.Errorf(
+				"%w: network %s has no genesis validators root",
+				ethereum.ErrGenesisValidatorsRootInvalid,
+				network.Name,
+			))
+			continue
+		}
+
+		forkSchedule, err := networkForkSchedule(network)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%w: invalid network %s", err, network.Name))
+			continue
+		}
+
+		config := &ethereum.NetworkConfig{
+			Name:                  network.Name,
+			Preset:                preset,
+			GenesisValidatorsRoot: strings.TrimPrefix(network.GenesisValidatorsRoot, "0x"),
+			GenesisTime:           network.GenesisTime,
+			ForkSchedule:          forkSchedule,
+			PrysmFlags:            network.PrysmFlags,
+		}
+		if len(network.GenesisBlockHash) > 0 {
+			config.GenesisBlockIdentifier = &types.BlockIdentifier{
+				Index: 0,
+				Hash:  strings.TrimPrefix(network.GenesisBlockHash, "0x"),
+			}
+		}
+
+		if err := config.Validate(); err != nil {
+			problems = append(problems, fmt.Errorf("%w: invalid network %s", err, network.Name))
+			continue
+		}
+
+		networks[name] = config
+	}
+
+	return networks, problems
+}
+
+// networkForkSchedule returns the fork schedule of a custom
+// network, from either its full schedule or its genesis fork
+// version, or nil if it declares neither.
+func networkForkSchedule(network *NetworkSettings) (ethereum.ForkSchedule, error) {
+	if len(network.ForkSchedule) == 0 {
+		return ethereum.GenesisForkSchedule(network.GenesisForkVersion)
+	}
+
+	if len(network.GenesisForkVersion) > 0 {
+		return nil, fmt.Errorf(
+			"%w: genesis_fork_version and fork_schedule are both set",
+			ethereum.ErrForkScheduleInvalid,
+		)
+	}
+
+	schedule := make(ethereum.ForkSchedule, len(network.ForkSchedule))
+	for i, fork := range network.ForkSchedule {
+		version, err := ethereum.ParseForkVersion(fork.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		schedule[i] = &ethereum.ScheduledFork{
+			Epoch:   fork.Epoch,
+			Version: version,
+			Name:    strings.ToLower(fork.Name),
+		}
+	}
+
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,308 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// redacted replaces secrets when
+	// printing the configuration.
+	redacted = "REDACTED"
+)
+
+// Settings are the raw configuration values read from a single
+// source (configuration file, ENVs or CLI flags). Unset values
+// are left empty so sources can be layered on top of each other.
+type Settings struct {
+	Mode           string         `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Network        string         `json:"network,omitempty" yaml:"network,omitempty"`
+	Port           int            `json:"port,omitempty" yaml:"port,omitempty"`
+	BeaconRPC      string         `json:"beacon_rpc,omitempty" yaml:"beacon_rpc,omitempty"`
+	Web3Providers  []string       `json:"web3_providers,omitempty" yaml:"web3_providers,omitempty"`
+	TracesExporter string         `json:"traces_exporter,omitempty" yaml:"traces_exporter,omitempty"`
+	Prysm          *PrysmSettings `json:"prysm,omitempty" yaml:"prysm,omitempty"`
+	Readiness      *ReadySettings `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+
+	// ParticipationMetadata is a pointer
+	// because false is a valid override.
+	ParticipationMetadata *bool `json:"participation_metadata,omitempty" yaml:"participation_metadata,omitempty"`
+
+	// Networks are custom networks (e.g. local devnets)
+	// that can be selected by name in Network.
+	Networks []*NetworkSettings `json:"networks,omitempty" yaml:"networks,omitempty"`
+}
+
+// NetworkSettings are the raw settings
+// describing a custom network.
+type NetworkSettings struct {
+	Name                  string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Preset                string   `json:"preset,omitempty" yaml:"preset,omitempty"`
+	GenesisValidatorsRoot string   `json:"genesis_validators_root,omitempty" yaml:"genesis_validators_root,omitempty"`
+	GenesisTime           int64    `json:"genesis_time,omitempty" yaml:"genesis_time,omitempty"`
+	GenesisBlockHash      string   `json:"genesis_block_hash,omitempty" yaml:"genesis_block_hash,omitempty"`
+	GenesisForkVersion    string   `json:"genesis_fork_version,omitempty" yaml:"genesis_fork_version,omitempty"`
+	PrysmFlags            []string `json:"prysm_flags,omitempty" yaml:"prysm_flags,omitempty"`
+
+	// ForkSchedule lists every fork of the network, starting
+	// at genesis. It replaces GenesisForkVersion, which only
+	// describes networks that have not forked.
+	ForkSchedule []*ForkSettings `json:"fork_schedule,omitempty" yaml:"fork_schedule,omitempty"`
+}
+
+// ForkSettings are the raw settings of
+// a fork of a custom network.
+type ForkSettings struct {
+	Epoch   uint64 `json:"epoch" yaml:"epoch"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// PrysmSettings are the raw settings used
+// to launch a local prysm.
+type PrysmSettings struct {
+	Binary            string   `json:"binary,omitempty" yaml:"binary,omitempty"`
+	CheckpointSyncURL string   `json:"checkpoint_sync_url,omitempty" yaml:"checkpoint_sync_url,omitempty"`
+	ExtraArgs         []string `json:"extra_args,omitempty" yaml:"extra_args,omitempty"`
+}
+
+// ReadySettings are the raw readiness thresholds. Pointers
+// are used because zero is a valid threshold.
+type ReadySettings struct {
+	MaxSlotLag *int64 `json:"max_slot_lag,omitempty" yaml:"max_slot_lag,omitempty"`
+	MinPeers   *int   `json:"min_peers,omitempty" yaml:"min_peers,omitempty"`
+}
+
+// DefaultSettings returns the Settings used
+// when no source populates a value.
+func DefaultSettings() *Settings {
+	maxSlotLag := int64(DefaultReadyMaxSlotLag)
+	minPeers := DefaultReadyMinPeers
+
+	return &Settings{
+		Web3Providers:  []string{DefaultHTTPWeb3Provider},
+		TracesExporter: DefaultTracesExporter,
+		Prysm: &PrysmSettings{
+			Binary: DefaultPrysmBinary,
+		},
+		Readiness: &ReadySettings{
+			MaxSlotLag: &maxSlotLag,
+			MinPeers:   &minPeers,
+		},
+	}
+}
+
+// SettingsFromFile reads Settings from a JSON (.json)
+// or YAML (any other extension) configuration file.
+func SettingsFromFile(path string) (*Settings, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read configuration file %s", err, path)
+	}
+
+	settings := &Settings{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(strings.NewReader(string(content)))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(settings)
+	} else {
+		decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+		decoder.KnownFields(true)
+		err = decoder.Decode(settings)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse configuration file %s", err, path)
+	}
+
+	return settings, nil
+}
+
+// SettingsFromEnv reads Settings from the ENVs in the
+// environment. Every ENV that cannot be parsed is
+// reported in the returned errors.
+func SettingsFromEnv() (*Settings, []error) {
+	settings := &Settings{
+		Mode:           os.Getenv(ModeEnv),
+		Network:        os.Getenv(NetworkEnv),
+		BeaconRPC:      os.Getenv(BeaconRPCEnv),
+		TracesExporter: os.Getenv(TracesExporterEnv),
+		Prysm: &PrysmSettings{
+			Binary:            os.Getenv(PrysmBinaryEnv),
+			CheckpointSyncURL: os.Getenv(CheckpointSyncURLEnv),
+			ExtraArgs:         strings.Fields(os.Getenv(PrysmExtraArgsEnv)),
+		},
+		Readiness: &ReadySettings{},
+	}
+	errs := []error{}
+
+	if providers := os.Getenv(HTTPWeb3ProviderEnv); len(providers) > 0 {
+		settings.Web3Providers = SplitList(providers)
+	}
+
+	if portValue := os.Getenv(PortEnv); len(portValue) > 0 {
+		port, err := strconv.Atoi(portValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: unable to parse %s %s", err, PortEnv, portValue))
+		} else {
+			settings.Port = port
+		}
+	}
+
+	if lagValue := os.Getenv(ReadyMaxSlotLagEnv); len(lagValue) > 0 {
+		lag, err := strconv.ParseInt(lagValue, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: unable to parse %s %s", err, ReadyMaxSlotLagEnv, lagValue))
+		} else {
+			settings.Readiness.MaxSlotLag = &lag
+		}
+	}
+
+	if metadataValue := os.Getenv(ParticipationMetadataEnv); len(metadataValue) > 0 {
+		metadata, err := strconv.ParseBool(metadataValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: unable to parse %s %s", err, ParticipationMetadataEnv, metadataValue))
+		} else {
+			settings.ParticipationMetadata = &metadata
+		}
+	}
+
+	if peersValue := os.Getenv(ReadyMinPeersEnv); len(peersValue) > 0 {
+		peers, err := strconv.Atoi(peersValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: unable to parse %s %s", err, ReadyMinPeersEnv, peersValue))
+		} else {
+			settings.Readiness.MinPeers = &peers
+		}
+	}
+
+	return settings, errs
+}
+
+// SplitList splits a comma-separated list,
+// dropping empty entries.
+func SplitList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// Merge returns a copy of s with every value
+// populated in override replacing its own.
+func (s *Settings) Merge(override *Settings) *Settings {
+	merged := *s
+	merged.Prysm = &PrysmSettings{}
+	merged.Readiness = &ReadySettings{}
+	if s.Prysm != nil {
+		*merged.Prysm = *s.Prysm
+	}
+	if s.Readiness != nil {
+		*merged.Readiness = *s.Readiness
+	}
+
+	if override == nil {
+		return &merged
+	}
+
+	mergeString(&merged.Mode, override.Mode)
+	mergeString(&merged.Network, override.Network)
+	mergeString(&merged.BeaconRPC, override.BeaconRPC)
+	mergeString(&merged.TracesExporter, override.TracesExporter)
+	if override.Port != 0 {
+		merged.Port = override.Port
+	}
+	if len(override.Web3Providers) > 0 {
+		merged.Web3Providers = override.Web3Providers
+	}
+	if len(override.Networks) > 0 {
+		merged.Networks = override.Networks
+	}
+	if override.ParticipationMetadata != nil {
+		merged.ParticipationMetadata = override.ParticipationMetadata
+	}
+
+	if override.Prysm != nil {
+		mergeString(&merged.Prysm.Binary, override.Prysm.Binary)
+		mergeString(&merged.Prysm.CheckpointSyncURL, override.Prysm.CheckpointSyncURL)
+		if len(override.Prysm.ExtraArgs) > 0 {
+			merged.Prysm.ExtraArgs = override.Prysm.ExtraArgs
+		}
+	}
+
+	if override.Readiness != nil {
+		if override.Readiness.MaxSlotLag != nil {
+			merged.Readiness.MaxSlotLag = override.Readiness.MaxSlotLag
+		}
+		if override.Readiness.MinPeers != nil {
+			merged.Readiness.MinPeers = override.Readiness.MinPeers
+		}
+	}
+
+	return &merged
+}
+
+func mergeString(dst *string, override string) {
+	if len(override) > 0 {
+		*dst = override
+	}
+}
+
+// Redacted returns a copy of s with credentials, paths and
+// queries removed from every URL, as these commonly carry
+// provider API keys.
+func (s *Settings) Redacted() *Settings {
+	redactedSettings := s.Merge(nil)
+
+	// Empty entries select the local prysm, so the list is
+	// split without dropping them to keep every position.
+	beacons := strings.Split(s.BeaconRPC, ",")
+	for i, beacon := range beacons {
+		beacons[i] = RedactURL(strings.TrimSpace(beacon))
+	}
+	redactedSettings.BeaconRPC = strings.Join(beacons, ",")
+	redactedSettings.Prysm.CheckpointSyncURL = RedactURL(redactedSettings.Prysm.CheckpointSyncURL)
+
+	redactedSettings.Web3Providers = make([]string, len(s.Web3Providers))
+	for i, provider := range s.Web3Providers {
+		redactedSettings.Web3Providers[i] = RedactURL(provider)
+	}
+
+	return redactedSettings
+}
+
+// RedactURL removes the password, path and query from raw.
+// Values that are not URLs with a scheme are returned as-is.
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return raw
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+	if len(strings.Trim(u.Path, "/")) > 0 {
+		u.Path = "/" + redacted
+		u.RawPath = ""
+	}
+	if len(u.RawQuery) > 0 {
+		u.RawQuery = redacted
+	}
+	u.Fragment = ""
+
+	return u.String()
+}
@@ -0,0 +1,390 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	// EffectiveBalanceSubAccount is the sub-account holding
+	// the effective balance of a validator, which determines
+	// its rewards and voting weight.
+	EffectiveBalanceSubAccount = "effective"
+
+	// PendingDepositSubAccount is the sub-account holding the
+	// balance of a validator that is not active yet. Top-ups of
+	// an active validator are credited to its balance as soon as
+	// their deposit is included in a block, so they are never
+	// pending here. Deposits not included yet are listed by the
+	// pending_deposits call method.
+	PendingDepositSubAccount = "pending_deposit"
+
+	// ValidatorStatusUnknown is reported for a public key
+	// that is not in the validator registry (yet).
+	ValidatorStatusUnknown = "unknown"
+
+	// ValidatorStatusPending is reported for a validator
+	// waiting to be activated.
+	ValidatorStatusPending = "pending"
+
+	// ValidatorStatusActive is reported for an active validator.
+	ValidatorStatusActive = "active"
+
+	// ValidatorStatusExiting is reported for a validator that
+	// initiated an exit and cannot withdraw yet.
+	ValidatorStatusExiting = "exiting"
+
+	// ValidatorStatusSlashed is reported for a slashed
+	// validator that cannot withdraw yet.
+	ValidatorStatusSlashed = "slashed"
+
+	// ValidatorStatusWithdrawable is reported for a validator
+	// whose balance can be withdrawn.
+	ValidatorStatusWithdrawable = "withdrawable"
+
+	// FarFutureEpoch is the epoch used by the
+	// spec for events that are not scheduled.
+	FarFutureEpoch = uint64(math.MaxUint64)
+
+	// BLSWithdrawalPrefix is the first byte of withdrawal
+	// credentials committing to a BLS withdrawal key.
+	BLSWithdrawalPrefix = byte(0x00)
+
+	// ExecutionWithdrawalPrefix is the first byte of withdrawal
+	// credentials committing to an execution layer address.
+	ExecutionWithdrawalPrefix = byte(0x01)
+
+	// executionAddressOffset is the offset of the execution
+	// layer address in 0x01 withdrawal credentials, which
+	// are zero-padded after the prefix.
+	executionAddressOffset = 12
+
+	// gweiToWei converts Gwei balances to the
+	// 18 decimals of Currency.
+	gweiToWei = 1e9
+)
+
+var (
+	// ErrInvalidAddress is returned when an account
+	// address is not a hex-encoded public key.
+	ErrInvalidAddress = errors.New("invalid address")
+
+	// ErrWithdrawalCredentialsInvalid is returned when
+	// withdrawal credentials are not 32 bytes long.
+	ErrWithdrawalCredentialsInvalid = errors.New("withdrawal credentials invalid")
+
+	// ErrSubAccountInvalid is returned when a
+	// sub-account is not supported.
+	ErrSubAccountInvalid = errors.New("sub-account invalid")
+
+	// SubAccounts are all supported validator sub-accounts.
+	SubAccounts = []string{
+		EffectiveBalanceSubAccount,
+		PendingDepositSubAccount,
+	}
+)
+
+// ParseValidatorAddress decodes the hex-encoded
+// public key used as a validator account address.
+func ParseValidatorAddress(address string) ([]byte, error) {
+	pubkey, err := hex.DecodeString(trimHash(address))
+	if err != nil || len(pubkey) != PubkeyLength {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, address)
+	}
+
+	return pubkey, nil
+}
+
+// ParseWithdrawalCredentialsAddress decodes the hex-encoded
+// withdrawal credentials used as the address of the account
+// aggregating all validators sharing them.
+func ParseWithdrawalCredentialsAddress(address string) ([]byte, error) {
+	creds, err := hex.DecodeString(trimHash(address))
+	if err != nil || len(creds) != rootLength {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, address)
+	}
+
+	switch creds[0] {
+	case BLSWithdrawalPrefix:
+	case ExecutionWithdrawalPrefix:
+		for _, b := range creds[1:executionAddressOffset] {
+			if b != 0 {
+				return nil, fmt.Errorf("%w: %s is not padded", ErrInvalidAddress, address)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown withdrawal prefix in %s", ErrInvalidAddress, address)
+	}
+
+	return creds, nil
+}
+
+// ValidatorAddress returns the account
+// address of the validator with pubkey.
+func ValidatorAddress(pubkey []byte) string {
+	return "0x" + hex.EncodeToString(pubkey)
+}
+
+// Balance returns the balance of an account at the chain head.
+// Validators and balances are read at the epoch of the head
+// block, so that they match the returned block identifier.
+// The address of a validator account is its public key, and a
+// public key that is not in the registry yet has no balance.
+// The address of a withdrawal credentials account is the 32-byte
+// credentials, and its balance is the sum of the balances of all
+// validators sharing them.
+func (ec *Client) Balance(
+	ctx context.Context,
+	account *RosettaTypes.AccountIdentifier,
+) (*RosettaTypes.AccountBalanceResponse, error) {
+	ctx, span := tracer.Start(ctx, "Client.Balance")
+	defer span.End()
+
+	var pubkey, creds []byte
+	var err error
+	if len(trimHash(account.Address)) == 2*rootLength {
+		creds, err = ParseWithdrawalCredentialsAddress(account.Address)
+	} else {
+		pubkey, err = ParseValidatorAddress(account.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	subAccount := ""
+	if account.SubAccount != nil {
+		subAccount = account.SubAccount.Address
+	}
+	if err := checkSubAccount(subAccount); err != nil {
+		return nil, err
+	}
+
+	chainHead, err := ec.beaconChainClient.GetChainHead(ctx, &types.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get chain head", err)
+	}
+	epoch := chainHead.HeadSlot / ec.network.Preset.SlotsPerEpoch
+
+	response := &RosettaTypes.AccountBalanceResponse{
+		BlockIdentifier: &RosettaTypes.BlockIdentifier{
+			Index: int64(chainHead.HeadSlot),
+			Hash:  hex.EncodeToString(chainHead.HeadBlockRoot),
+		},
+	}
+
+	if creds != nil {
+		return ec.withdrawalCredentialsBalance(ctx, response, creds, epoch, subAccount)
+	}
+
+	validators, err := ec.beaconChainClient.ListValidators(ctx, &pb.ListValidatorsRequest{
+		QueryFilter: &pb.ListValidatorsRequest_Epoch{Epoch: epoch},
+		PublicKeys:  [][]byte{pubkey},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not list validators", err)
+	}
+	if len(validators.ValidatorList) < 1 {
+		response.Balances = []*RosettaTypes.Amount{Amount(0)}
+		response.Metadata = map[string]interface{}{"status": ValidatorStatusUnknown}
+		return response, nil
+	}
+	validator := validators.ValidatorList[0].Validator
+
+	balances, err := ec.beaconChainClient.ListValidatorBalances(ctx, &pb.ListValidatorBalancesRequest{
+		QueryFilter: &pb.ListValidatorBalancesRequest_Epoch{Epoch: epoch},
+		PublicKeys:  [][]byte{pubkey},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not list validator balances", err)
+	}
+	if len(balances.Balances) < 1 {
+		return nil, fmt.Errorf("%w: no balance for %s", ErrValidatorNotFound, account.Address)
+	}
+	balance := balances.Balances[0]
+
+	response.Balances = []*RosettaTypes.Amount{
+		Amount(SubAccountBalance(validator, balance.Balance, epoch, subAccount)),
+	}
+	response.Metadata = ValidatorMetadata(validator, balance.Index, epoch)
+
+	return response, nil
+}
+
+// withdrawalCredentialsBalance populates response with the
+// balance of all validators with withdrawal credentials creds.
+func (ec *Client) withdrawalCredentialsBalance(
+	ctx context.Context,
+	response *RosettaTypes.AccountBalanceResponse,
+	creds []byte,
+	epoch uint64,
+	subAccount string,
+) (*RosettaTypes.AccountBalanceResponse, error) {
+	indices, err := ec.validators.WithdrawalIndices(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	total := uint64(0)
+	breakdown := make([]map[string]interface{}, 0, len(indices))
+
+	// Prysm paginates filtered results, so indices are requested
+	// in chunks that fit in a single page.
+	for start := 0; start < len(indices); start += validatorsPageSize {
+		end := start + validatorsPageSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+		chunk := indices[start:end]
+
+		validators, err := ec.beaconChainClient.ListValidators(ctx, &pb.ListValidatorsRequest{
+			QueryFilter: &pb.ListValidatorsRequest_Epoch{Epoch: epoch},
+			Indices:     chunk,
+			PageSize:    validatorsPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not list validators", err)
+		}
+
+		balances, err := ec.beaconChainClient.ListValidatorBalances(ctx, &pb.ListValidatorBalancesRequest{
+			QueryFilter: &pb.ListValidatorBalancesRequest_Epoch{Epoch: epoch},
+			Indices:     chunk,
+			PageSize:    validatorsPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not list validator balances", err)
+		}
+
+		if len(validators.ValidatorList) != len(chunk) || len(balances.Balances) != len(chunk) {
+			return nil, fmt.Errorf(
+				"%w: requested %d validators but got %d validators and %d balances",
+				ErrValidatorNotFound,
+				len(chunk),
+				len(validators.ValidatorList),
+				len(balances.Balances),
+			)
+		}
+
+		// Both lists are sorted by index.
+		for i, container := range validators.ValidatorList {
+			balance := balances.Balances[i]
+			if container.Index != balance.Index {
+				return nil, fmt.Errorf(
+					"%w: balance of validator %d returned for validator %d",
+					ErrValidatorNotFound,
+					balance.Index,
+					container.Index,
+				)
+			}
+
+			amount := SubAccountBalance(container.Validator, balance.Balance, epoch, subAccount)
+			total += amount
+			breakdown = append(breakdown, map[string]interface{}{
+				"index":   int64(container.Index),
+				"address": ValidatorAddress(container.Validator.PublicKey),
+				"status":  ValidatorStatus(container.Validator, epoch),
+				"balance": Amount(amount).Value,
+			})
+		}
+	}
+
+	response.Balances = []*RosettaTypes.Amount{Amount(total)}
+	response.Metadata = map[string]interface{}{
+		"validators": breakdown,
+	}
+
+	return response, nil
+}
+
+// checkSubAccount returns an error if subAccount is
+// not empty and not one of SubAccounts.
+func checkSubAccount(subAccount string) error {
+	if len(subAccount) == 0 {
+		return nil
+	}
+
+	for _, supported := range SubAccounts {
+		if subAccount == supported {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSubAccountInvalid, subAccount)
+}
+
+// SubAccountBalance returns the Gwei amount held by subAccount
+// of validator at epoch, given its actual balance.
+func SubAccountBalance(validator *pb.Validator, balance uint64, epoch uint64, subAccount string) uint64 {
+	switch subAccount {
+	case EffectiveBalanceSubAccount:
+		return validator.EffectiveBalance
+	case PendingDepositSubAccount:
+		if ValidatorStatus(validator, epoch) == ValidatorStatusPending {
+			return balance
+		}
+
+		return 0
+	default:
+		return balance
+	}
+}
+
+// ValidatorStatus returns the status of validator at epoch.
+func ValidatorStatus(validator *pb.Validator, epoch uint64) string {
+	switch {
+	case validator.WithdrawableEpoch <= epoch:
+		return ValidatorStatusWithdrawable
+	case validator.Slashed:
+		return ValidatorStatusSlashed
+	case validator.ExitEpoch != FarFutureEpoch:
+		return ValidatorStatusExiting
+	case validator.ActivationEpoch <= epoch:
+		return ValidatorStatusActive
+	default:
+		return ValidatorStatusPending
+	}
+}
+
+// ValidatorMetadata returns the account metadata of validator
+// at epoch. Epochs that are not scheduled are omitted.
+func ValidatorMetadata(validator *pb.Validator, index uint64, epoch uint64) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"index":   int64(index),
+		"status":  ValidatorStatus(validator, epoch),
+		"slashed": validator.Slashed,
+	}
+
+	for key, value := range map[string]uint64{
+		"activation_eligibility_epoch": validator.ActivationEligibilityEpoch,
+		"activation_epoch":             validator.ActivationEpoch,
+		"exit_epoch":                   validator.ExitEpoch,
+		"withdrawable_epoch":           validator.WithdrawableEpoch,
+	} {
+		if value != FarFutureEpoch {
+			metadata[key] = int64(value)
+		}
+	}
+
+	return metadata
+}
+
+// Amount returns the *RosettaTypes.Amount
+// of a Gwei balance.
+func Amount(gwei uint64) *RosettaTypes.Amount {
+	return &RosettaTypes.Amount{
+		Value:    Wei(gwei).String(),
+		Currency: Currency,
+	}
+}
+
+// Wei converts an amount in Gwei to wei.
+func Wei(gwei uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gwei), big.NewInt(gweiToWei))
+}
@@ -0,0 +1,324 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+)
+
+// fakeAccountClient serves a single validator
+// with pubkey testPubkey(index), recording the
+// epochs validators and balances are read at.
+type fakeAccountClient struct {
+	fakeRegistryClient
+
+	validator *pb.Validator
+	index     uint64
+	balance   uint64
+	epochs    []uint64
+}
+
+func (c *fakeAccountClient) GetChainHead(
+	ctx context.Context,
+	req *types.Empty,
+	opts ...grpc.CallOption,
+) (*pb.ChainHead, error) {
+	return &pb.ChainHead{HeadSlot: 320, HeadBlockRoot: []byte{0x0a}}, nil
+}
+
+func (c *fakeAccountClient) ListValidators(
+	ctx context.Context,
+	req *pb.ListValidatorsRequest,
+	opts ...grpc.CallOption,
+) (*pb.Validators, error) {
+	if filter, ok := req.QueryFilter.(*pb.ListValidatorsRequest_Epoch); ok {
+		c.epochs = append(c.epochs, filter.Epoch)
+	}
+
+	if len(req.PublicKeys) == 0 {
+		return c.fakeRegistryClient.ListValidators(ctx, req, opts...)
+	}
+
+	res := &pb.Validators{}
+	if string(req.PublicKeys[0]) == string(testPubkey(c.index)) {
+		res.ValidatorList = append(res.ValidatorList, &pb.Validators_ValidatorContainer{
+			Index:     c.index,
+			Validator: c.validator,
+		})
+	}
+
+	return res, nil
+}
+
+func (c *fakeAccountClient) ListValidatorBalances(
+	ctx context.Context,
+	req *pb.ListValidatorBalancesRequest,
+	opts ...grpc.CallOption,
+) (*pb.ValidatorBalances, error) {
+	if filter, ok := req.QueryFilter.(*pb.ListValidatorBalancesRequest_Epoch); ok {
+		c.epochs = append(c.epochs, filter.Epoch)
+	}
+
+	if len(req.Indices) > 0 {
+		res := &pb.ValidatorBalances{}
+		for _, index := range req.Indices {
+			res.Balances = append(res.Balances, &pb.ValidatorBalances_Balance{
+				PublicKey: testPubkey(index),
+				Index:     index,
+				Balance:   32000000000 + index,
+			})
+		}
+		return res, nil
+	}
+
+	return &pb.ValidatorBalances{
+		Balances: []*pb.ValidatorBalances_Balance{
+			{PublicKey: req.PublicKeys[0], Index: c.index, Balance: c.balance},
+		},
+	}, nil
+}
+
+func TestValidatorStatus(t *testing.T) {
+	tests := map[string]struct {
+		validator *pb.Validator
+
+		expectedStatus string
+	}{
+		"pending": {
+			validator: &pb.Validator{
+				ActivationEpoch:   FarFutureEpoch,
+				ExitEpoch:         FarFutureEpoch,
+				WithdrawableEpoch: FarFutureEpoch,
+			},
+			expectedStatus: ValidatorStatusPending,
+		},
+		"activation scheduled": {
+			validator: &pb.Validator{
+				ActivationEpoch:   11,
+				ExitEpoch:         FarFutureEpoch,
+				WithdrawableEpoch: FarFutureEpoch,
+			},
+			expectedStatus: ValidatorStatusPending,
+		},
+		"active": {
+			validator: &pb.Validator{
+				ActivationEpoch:   10,
+				ExitEpoch:         FarFutureEpoch,
+				WithdrawableEpoch: FarFutureEpoch,
+			},
+			expectedStatus: ValidatorStatusActive,
+		},
+		"exiting": {
+			validator: &pb.Validator{
+				ExitEpoch:         12,
+				WithdrawableEpoch: 268,
+			},
+			expectedStatus: ValidatorStatusExiting,
+		},
+		"slashed": {
+			validator: &pb.Validator{
+				Slashed:           true,
+				ExitEpoch:         9,
+				WithdrawableEpoch: 8201,
+			},
+			expectedStatus: ValidatorStatusSlashed,
+		},
+		"withdrawable": {
+			validator: &pb.Validator{
+				Slashed:           true,
+				ExitEpoch:         1,
+				WithdrawableEpoch: 10,
+			},
+			expectedStatus: ValidatorStatusWithdrawable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatus, ValidatorStatus(test.validator, 10))
+		})
+	}
+}
+
+func TestClient_Balance(t *testing.T) {
+	ctx := context.Background()
+	blockIdentifier := &RosettaTypes.BlockIdentifier{Index: 320, Hash: "0a"}
+	pending := &pb.Validator{
+		EffectiveBalance:           31000000000,
+		ActivationEligibilityEpoch: 9,
+		ActivationEpoch:            FarFutureEpoch,
+		ExitEpoch:                  FarFutureEpoch,
+		WithdrawableEpoch:          FarFutureEpoch,
+	}
+	exiting := &pb.Validator{
+		EffectiveBalance:           32000000000,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            0,
+		ExitEpoch:                  12,
+		WithdrawableEpoch:          268,
+	}
+
+	tests := map[string]struct {
+		validator  *pb.Validator
+		address    string
+		subAccount string
+
+		expectedBalance  string
+		expectedMetadata map[string]interface{}
+		expectedErr      error
+	}{
+		"pending balance": {
+			validator:       pending,
+			address:         ValidatorAddress(testPubkey(7)),
+			expectedBalance: "31000000005000000000",
+			expectedMetadata: map[string]interface{}{
+				"index":                        int64(7),
+				"status":                       ValidatorStatusPending,
+				"slashed":                      false,
+				"activation_eligibility_epoch": int64(9),
+			},
+		},
+		"pending deposit": {
+			validator:       pending,
+			address:         ValidatorAddress(testPubkey(7)),
+			subAccount:      PendingDepositSubAccount,
+			expectedBalance: "31000000005000000000",
+		},
+		"exiting effective balance": {
+			validator:       exiting,
+			address:         ValidatorAddress(testPubkey(7)),
+			subAccount:      EffectiveBalanceSubAccount,
+			expectedBalance: "32000000000000000000",
+			expectedMetadata: map[string]interface{}{
+				"index":                        int64(7),
+				"status":                       ValidatorStatusExiting,
+				"slashed":                      false,
+				"activation_eligibility_epoch": int64(0),
+				"activation_epoch":             int64(0),
+				"exit_epoch":                   int64(12),
+				"withdrawable_epoch":           int64(268),
+			},
+		},
+		"exiting pending deposit": {
+			validator:       exiting,
+			address:         ValidatorAddress(testPubkey(7)),
+			subAccount:      PendingDepositSubAccount,
+			expectedBalance: "0",
+		},
+		"unknown validator": {
+			validator:        exiting,
+			address:          ValidatorAddress(testPubkey(8)),
+			expectedBalance:  "0",
+			expectedMetadata: map[string]interface{}{"status": ValidatorStatusUnknown},
+		},
+		"withdrawal credentials": {
+			address:         "0x" + hex.EncodeToString(testCredentials(1)),
+			expectedBalance: "96000000012000000000",
+			expectedMetadata: map[string]interface{}{
+				"validators": []map[string]interface{}{
+					{
+						"index":   int64(1),
+						"address": ValidatorAddress(testPubkey(1)),
+						"status":  ValidatorStatusActive,
+						"balance": "32000000001000000000",
+					},
+					{
+						"index":   int64(4),
+						"address": ValidatorAddress(testPubkey(4)),
+						"status":  ValidatorStatusActive,
+						"balance": "32000000004000000000",
+					},
+					{
+						"index":   int64(7),
+						"address": ValidatorAddress(testPubkey(7)),
+						"status":  ValidatorStatusActive,
+						"balance": "32000000007000000000",
+					},
+				},
+			},
+		},
+		"withdrawal credentials effective balance": {
+			address:         "0x" + hex.EncodeToString(testCredentials(2)),
+			subAccount:      EffectiveBalanceSubAccount,
+			expectedBalance: "64000000000000000000",
+		},
+		"withdrawal credentials without validators": {
+			address:         "0x01000000000000000000000011223344556677889900aabbccddeeff00112233",
+			expectedBalance: "0",
+			expectedMetadata: map[string]interface{}{
+				"validators": []map[string]interface{}{},
+			},
+		},
+		"unpadded execution withdrawal credentials": {
+			address:     "0x01ff000000000000000000001122334455667788990011223344556677889900",
+			expectedErr: ErrInvalidAddress,
+		},
+		"unknown withdrawal prefix": {
+			address:     "0x02000000000000000000000011223344556677889900aabbccddeeff00112233",
+			expectedErr: ErrInvalidAddress,
+		},
+		"invalid address": {
+			address:     "0xa0",
+			expectedErr: ErrInvalidAddress,
+		},
+		"invalid sub-account": {
+			address:     ValidatorAddress(testPubkey(7)),
+			subAccount:  "locked",
+			expectedErr: ErrSubAccountInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			beaconChainClient := &fakeAccountClient{
+				fakeRegistryClient: fakeRegistryClient{size: 8},
+				validator:          test.validator,
+				index:              7,
+				balance:            31000000005,
+			}
+			client := &Client{
+				network:           MainnetNetworkConfig,
+				beaconChainClient: beaconChainClient,
+				validators: NewValidatorRegistry(
+					beaconChainClient,
+					MainnetNetworkConfig,
+					DefaultValidatorRegistrySize,
+				),
+			}
+
+			account := &RosettaTypes.AccountIdentifier{Address: test.address}
+			if len(test.subAccount) > 0 {
+				account.SubAccount = &RosettaTypes.SubAccountIdentifier{Address: test.subAccount}
+			}
+
+			balance, err := client.Balance(ctx, account)
+			if test.expectedErr != nil {
+				assert.Nil(t, balance)
+				assert.True(t, errors.Is(err, test.expectedErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, blockIdentifier, balance.BlockIdentifier)
+
+			// Every read is pinned to the epoch of slot 320.
+			for _, epoch := range beaconChainClient.epochs {
+				assert.Equal(t, uint64(10), epoch)
+			}
+			assert.Equal(t, []*RosettaTypes.Amount{{
+				Value:    test.expectedBalance,
+				Currency: Currency,
+			}}, balance.Balances)
+			if test.expectedMetadata != nil {
+				assert.Equal(t, test.expectedMetadata, balance.Metadata)
+			}
+		})
+	}
+}
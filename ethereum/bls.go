@@ -0,0 +1,107 @@
+package ethereum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+const (
+	// BLS12381CurveType is the curve type of validator public
+	// keys: compressed 48-byte BLS12-381 G1 points.
+	//
+	// rosetta-sdk-go v0.6.5 does not know this curve, so requests
+	// carrying it are asserted by this implementation instead of
+	// the SDK (see services.NewBLSConstructionAPIController).
+	BLS12381CurveType types.CurveType = "bls12381"
+
+	// BLS12381SignatureType is the signature type of BLS12-381
+	// signatures: compressed 96-byte G2 points. Like
+	// BLS12381CurveType, it is unknown to rosetta-sdk-go v0.6.5.
+	BLS12381SignatureType types.SignatureType = "bls12381"
+
+	// signatureDST is the hash-to-curve domain separation tag
+	// of the proof of possession scheme used by eth2.
+	signatureDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+var (
+	// ErrUnableToDecompressPubkey is returned when a public key
+	// is not a valid compressed BLS12-381 G1 point.
+	ErrUnableToDecompressPubkey = errors.New("unable to decompress public key")
+
+	// ErrSignatureInvalid is returned when a signature is not a
+	// valid compressed BLS12-381 G2 point or does not verify.
+	ErrSignatureInvalid = errors.New("invalid signature")
+)
+
+// ValidatePubkey returns an error unless pubkey is a compressed
+// BLS12-381 public key usable by a validator: a point of the G1
+// subgroup other than the point at infinity (KeyValidate in the
+// BLS signature spec).
+func ValidatePubkey(pubkey []byte) error {
+	_, err := decompressPubkey(pubkey)
+	return err
+}
+
+// VerifySignature returns an error unless signature is a valid
+// BLS12-381 signature of root by pubkey (CoreVerify in the BLS
+// signature spec, with the eth2 ciphersuite).
+func VerifySignature(pubkey []byte, root [32]byte, signature []byte) error {
+	pubkeyPoint, err := decompressPubkey(pubkey)
+	if err != nil {
+		return err
+	}
+
+	if len(signature) != SignatureLength {
+		return fmt.Errorf("%w: %d bytes", ErrSignatureInvalid, len(signature))
+	}
+
+	g2 := bls12381.NewG2()
+	signaturePoint, err := g2.FromCompressed(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrSignatureInvalid, err.Error())
+	}
+
+	if !g2.InCorrectSubgroup(signaturePoint) {
+		return fmt.Errorf("%w: not in the G2 subgroup", ErrSignatureInvalid)
+	}
+
+	message, err := g2.HashToCurve(root[:], []byte(signatureDST))
+	if err != nil {
+		return fmt.Errorf("%w: unable to hash to curve", err)
+	}
+
+	// e(pubkey, H(root)) == e(G1, signature)
+	g1 := bls12381.NewG1()
+	engine := bls12381.NewEngine()
+	engine.AddPair(pubkeyPoint, message)
+	engine.AddPairInv(g1.One(), signaturePoint)
+	if !engine.Check() {
+		return fmt.Errorf("%w: does not verify", ErrSignatureInvalid)
+	}
+
+	return nil
+}
+
+// decompressPubkey returns the G1 point of a
+// public key accepted by ValidatePubkey.
+func decompressPubkey(pubkey []byte) (*bls12381.PointG1, error) {
+	if len(pubkey) != PubkeyLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrUnableToDecompressPubkey, len(pubkey))
+	}
+
+	g1 := bls12381.NewG1()
+	point, err := g1.FromCompressed(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnableToDecompressPubkey, err.Error())
+	}
+
+	if g1.IsZero(point) {
+		return nil, fmt.Errorf("%w: point at infinity", ErrUnableToDecompressPubkey)
+	}
+
+	return point, nil
+}
@@ -0,0 +1,126 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// g1Generator is the compressed generator of G1.
+const g1Generator = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+
+func TestValidatePubkey(t *testing.T) {
+	tests := map[string]struct {
+		pubkey string
+
+		expectedErr error
+	}{
+		"valid": {
+			pubkey: g1Generator,
+		},
+		"wrong length": {
+			pubkey:      g1Generator[:94],
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+		"uncompressed": {
+			pubkey:      "17" + g1Generator[2:],
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+		"point at infinity": {
+			pubkey:      "c0" + strings.Repeat("00", PubkeyLength-1),
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+		"not on curve": {
+			pubkey:      "80" + strings.Repeat("00", PubkeyLength-2) + "01",
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+		"not in subgroup": {
+			pubkey:      "80" + strings.Repeat("00", PubkeyLength-2) + "04",
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pubkey, err := hex.DecodeString(test.pubkey)
+			assert.NoError(t, err)
+
+			err = ValidatePubkey(pubkey)
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.expectedErr))
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	// sign_case_84d45c9c7cca6b92 of the eth2 BLS spec tests.
+	pubkey := "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a"
+	signature := "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb51580" +
+		"90352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"
+
+	tests := map[string]struct {
+		pubkey    string
+		root      [32]byte
+		signature string
+
+		expectedErr error
+	}{
+		"valid": {
+			pubkey:    pubkey,
+			signature: signature,
+		},
+		"other root": {
+			pubkey:      pubkey,
+			root:        [32]byte{0x01},
+			signature:   signature,
+			expectedErr: ErrSignatureInvalid,
+		},
+		"other public key": {
+			pubkey:      g1Generator,
+			signature:   signature,
+			expectedErr: ErrSignatureInvalid,
+		},
+		"invalid public key": {
+			pubkey:      "c0" + strings.Repeat("00", PubkeyLength-1),
+			signature:   signature,
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+		"wrong length": {
+			pubkey:      pubkey,
+			signature:   signature[:190],
+			expectedErr: ErrSignatureInvalid,
+		},
+		"not on curve": {
+			pubkey:      pubkey,
+			signature:   "a0" + strings.Repeat("00", SignatureLength-2) + "01",
+			expectedErr: ErrSignatureInvalid,
+		},
+		"point at infinity": {
+			pubkey:      pubkey,
+			signature:   "c0" + strings.Repeat("00", SignatureLength-1),
+			expectedErr: ErrSignatureInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pubkey, err := hex.DecodeString(test.pubkey)
+			assert.NoError(t, err)
+			signature, err := hex.DecodeString(test.signature)
+			assert.NoError(t, err)
+
+			err = VerifySignature(pubkey, test.root, signature)
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.expectedErr), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,761 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	types "github.com/gogo/protobuf/types"
+	ethv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ValidatorStatusMethod returns the status of a
+	// validator, including deposits not yet processed.
+	ValidatorStatusMethod = "validator_status"
+
+	// CommitteesMethod returns the beacon
+	// committees of an epoch.
+	CommitteesMethod = "committees"
+
+	// ProposerDutiesMethod returns the block
+	// proposers of an epoch.
+	ProposerDutiesMethod = "proposer_duties"
+
+	// ValidatorDutiesMethod returns the proposer slots and
+	// attestation assignments of validators in an epoch.
+	ValidatorDutiesMethod = "validator_duties"
+
+	// FinalityCheckpointsMethod returns the finalized and
+	// justified checkpoints of the chain head.
+	FinalityCheckpointsMethod = "finality_checkpoints"
+
+	// ChainConfigMethod returns the consensus
+	// config of the beacon node.
+	ChainConfigMethod = "chain_config"
+
+	// ParticipationMethod returns the attestation
+	// participation of validators in an epoch.
+	ParticipationMethod = "participation"
+
+	// PendingDepositsMethod returns the deposits seen on
+	// the execution layer that are not processed yet.
+	PendingDepositsMethod = "pending_deposits"
+
+	// uintPattern is the pattern of the
+	// decimal uint64 call parameters.
+	uintPattern = "^[0-9]+$"
+
+	// pubkeyPattern is the pattern of the
+	// public key call parameters.
+	pubkeyPattern = "^0x[0-9a-fA-F]{96}$"
+
+	// dutiesLookahead is the number of epochs after the
+	// head epoch for which duties are known: committees
+	// are shuffled with a seed fixed one epoch ahead.
+	dutiesLookahead = 1
+)
+
+// CallSchema is the subset of JSON schema used to
+// document the parameters of /call methods.
+type CallSchema struct {
+	Type                 string                 `json:"type"`
+	Description          string                 `json:"description,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Properties           map[string]*CallSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *CallSchema            `json:"items,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+}
+
+// CallMethodSpec documents a /call method.
+type CallMethodSpec struct {
+	Description string      `json:"description"`
+	Parameters  *CallSchema `json:"parameters"`
+}
+
+// callParameters returns the schema of the
+// parameters object of a /call method.
+func callParameters(properties map[string]*CallSchema, required ...string) *CallSchema {
+	additionalProperties := false
+	return &CallSchema{
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: &additionalProperties,
+	}
+}
+
+// epochParameter documents the optional epoch
+// parameter of methods defaulting to the head.
+var epochParameter = &CallSchema{
+	Type:        "string",
+	Description: "decimal epoch, the epoch of the chain head if omitted",
+	Pattern:     uintPattern,
+}
+
+// CallMethodSpecs documents every supported /call method.
+var CallMethodSpecs = map[string]*CallMethodSpec{
+	ValidatorStatusMethod: {
+		Description: "Status of a validator as seen by the beacon node, " +
+			"including validators whose deposits are not processed yet.",
+		Parameters: callParameters(map[string]*CallSchema{
+			"validator": {
+				Type:        "string",
+				Description: "0x-prefixed public key or decimal index of the validator",
+			},
+		}, "validator"),
+	},
+	CommitteesMethod: {
+		Description: "Beacon committees of an epoch, by slot and committee index.",
+		Parameters: callParameters(map[string]*CallSchema{
+			"epoch": epochParameter,
+		}),
+	},
+	ProposerDutiesMethod: {
+		Description: "Validators assigned to propose the blocks of an epoch, " +
+			"up to the epoch after the chain head.",
+		Parameters: callParameters(map[string]*CallSchema{
+			"epoch": epochParameter,
+		}),
+	},
+	ValidatorDutiesMethod: {
+		Description: "Proposer slots and attestation committee assignments of " +
+			"validators in an epoch, up to the epoch after the chain head.",
+		Parameters: callParameters(map[string]*CallSchema{
+			"validators": {
+				Type:        "array",
+				Description: "0x-prefixed public keys of the validators",
+				Items: &CallSchema{
+					Type:    "string",
+					Pattern: pubkeyPattern,
+				},
+			},
+			"epoch": epochParameter,
+		}, "validators"),
+	},
+	FinalityCheckpointsMethod: {
+		Description: "Finalized, current justified and previous " +
+			"justified checkpoints of the chain head.",
+		Parameters: callParameters(map[string]*CallSchema{}),
+	},
+	ChainConfigMethod: {
+		Description: "Consensus config of the beacon node.",
+		Parameters:  callParameters(map[string]*CallSchema{}),
+	},
+	ParticipationMethod: {
+		Description: "Global participation rate, voted and eligible ether in Gwei, " +
+			"active validator count and total active balance of a completed epoch, " +
+			"with the validator queue of the chain head.",
+		Parameters: callParameters(map[string]*CallSchema{
+			"epoch": {
+				Type:        "string",
+				Description: "decimal epoch before the head epoch, the epoch before the head if omitted",
+				Pattern:     uintPattern,
+			},
+		}),
+	},
+	PendingDepositsMethod: {
+		Description: "Deposits to the deposit contract, read through the web3 provider, " +
+			"whose validators the beacon node has not added to the registry yet, " +
+			"with the epoch they are estimated to be processed in.",
+		Parameters: callParameters(map[string]*CallSchema{}),
+	},
+}
+
+// Call invokes the /call method of request.
+func (ec *Client) Call(
+	ctx context.Context,
+	request *RosettaTypes.CallRequest,
+) (*RosettaTypes.CallResponse, error) {
+	ctx, span := tracer.Start(ctx, "Client.Call")
+	defer span.End()
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch request.Method {
+	case ValidatorStatusMethod:
+		result, err = ec.validatorStatus(ctx, request.Parameters)
+	case CommitteesMethod:
+		result, err = ec.committees(ctx, request.Parameters)
+	case ProposerDutiesMethod:
+		result, err = ec.proposerDuties(ctx, request.Parameters)
+	case ValidatorDutiesMethod:
+		result, err = ec.validatorDuties(ctx, request.Parameters)
+	case FinalityCheckpointsMethod:
+		result, err = ec.finalityCheckpoints(ctx, request.Parameters)
+	case ChainConfigMethod:
+		result, err = ec.chainConfig(ctx, request.Parameters)
+	case ParticipationMethod:
+		result, err = ec.participation(ctx, request.Parameters)
+	case PendingDepositsMethod:
+		result, err = ec.pendingDeposits(ctx, request.Parameters)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrCallMethodInvalid, request.Method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCallOutputMarshal, err.Error())
+	}
+
+	var output map[string]interface{}
+	if err := json.Unmarshal(b, &output); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCallOutputMarshal, err.Error())
+	}
+
+	return &RosettaTypes.CallResponse{
+		Result: output,
+	}, nil
+}
+
+// validatorStatusParameters are the
+// parameters of validator_status.
+type validatorStatusParameters struct {
+	Validator string `json:"validator"`
+}
+
+// validatorStatusResult is the result of validator_status.
+// Index is omitted until the deposit of the validator is
+// processed.
+type validatorStatusResult struct {
+	PublicKey                 string `json:"public_key"`
+	Index                     string `json:"index,omitempty"`
+	Status                    string `json:"status"`
+	Eth1DepositBlockNumber    string `json:"eth1_deposit_block_number"`
+	DepositInclusionSlot      string `json:"deposit_inclusion_slot"`
+	ActivationEpoch           string `json:"activation_epoch"`
+	PositionInActivationQueue string `json:"position_in_activation_queue"`
+}
+
+// validatorStatus implements validator_status.
+func (ec *Client) validatorStatus(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*validatorStatusResult, error) {
+	var params validatorStatusParameters
+	if err := decodeCallParameters(parameters, &params); err != nil {
+		return nil, err
+	}
+	if len(params.Validator) == 0 {
+		return nil, fmt.Errorf("%w: validator is required", ErrCallParametersInvalid)
+	}
+
+	pubkey, err := ec.validatorPubkey(ctx, params.Validator)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ec.validatorClient.ValidatorStatus(ctx, &pb.ValidatorStatusRequest{
+		PublicKey: pubkey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get validator status", err)
+	}
+
+	result := &validatorStatusResult{
+		PublicKey:                 ValidatorAddress(pubkey),
+		Status:                    res.Status.String(),
+		Eth1DepositBlockNumber:    strconv.FormatUint(res.Eth1DepositBlockNumber, 10),
+		DepositInclusionSlot:      strconv.FormatUint(res.DepositInclusionSlot, 10),
+		ActivationEpoch:           strconv.FormatUint(res.ActivationEpoch, 10),
+		PositionInActivationQueue: strconv.FormatUint(res.PositionInActivationQueue, 10),
+	}
+
+	index, err := ec.validators.Index(ctx, pubkey)
+	switch {
+	case errors.Is(err, ErrValidatorNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		result.Index = strconv.FormatUint(index, 10)
+	}
+
+	return result, nil
+}
+
+// validatorPubkey returns the public key of validator,
+// which is either a public key or an index.
+func (ec *Client) validatorPubkey(ctx context.Context, validator string) ([]byte, error) {
+	if pubkey, err := ParseValidatorAddress(validator); err == nil {
+		return pubkey, nil
+	}
+
+	index, err := strconv.ParseUint(validator, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: validator %q is neither a public key nor an index",
+			ErrCallParametersInvalid,
+			validator,
+		)
+	}
+
+	pubkey, err := ec.validators.Pubkey(ctx, index)
+	if errors.Is(err, ErrValidatorNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrCallParametersInvalid, err.Error())
+	}
+
+	return pubkey, err
+}
+
+// epochParameters are the parameters of
+// the methods querying an epoch.
+type epochParameters struct {
+	Epoch string `json:"epoch"`
+}
+
+// committee is a beacon committee of committeesResult.
+type committee struct {
+	Slot       string   `json:"slot"`
+	Index      string   `json:"index"`
+	Validators []string `json:"validators"`
+}
+
+// committeesResult is the result of committees.
+type committeesResult struct {
+	Epoch                string       `json:"epoch"`
+	ActiveValidatorCount string       `json:"active_validator_count"`
+	Committees           []*committee `json:"committees"`
+}
+
+// committees implements committees.
+func (ec *Client) committees(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*committeesResult, error) {
+	epoch, err := ec.epochParameter(ctx, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ec.beaconChainClient.ListBeaconCommittees(ctx, &pb.ListCommitteesRequest{
+		QueryFilter: &pb.ListCommitteesRequest_Epoch{Epoch: epoch},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not list committees of epoch %d", err, epoch)
+	}
+
+	slots := make([]uint64, 0, len(res.Committees))
+	for slot := range res.Committees {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	result := &committeesResult{
+		Epoch:                strconv.FormatUint(res.Epoch, 10),
+		ActiveValidatorCount: strconv.FormatUint(res.ActiveValidatorCount, 10),
+		Committees:           []*committee{},
+	}
+	for _, slot := range slots {
+		for index, item := range res.Committees[slot].GetCommittees() {
+			validators := make([]string, len(item.ValidatorIndices))
+			for i, validator := range item.ValidatorIndices {
+				validators[i] = strconv.FormatUint(validator, 10)
+			}
+
+			result.Committees = append(result.Committees, &committee{
+				Slot:       strconv.FormatUint(slot, 10),
+				Index:      strconv.Itoa(index),
+				Validators: validators,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// proposerDuty is a block proposal of proposerDutiesResult.
+type proposerDuty struct {
+	Slot           string `json:"slot"`
+	ValidatorIndex string `json:"validator_index"`
+	PublicKey      string `json:"public_key"`
+}
+
+// proposerDutiesResult is the result of proposer_duties.
+type proposerDutiesResult struct {
+	Epoch  string          `json:"epoch"`
+	Duties []*proposerDuty `json:"duties"`
+}
+
+// proposerDuties implements proposer_duties with the eth/v1
+// proposer duties of the epoch. Older prysm releases do not
+// serve them, in which case proposers are looked up among
+// the assignments of every validator in the epoch: one
+// page per validatorsPageSize active validators.
+func (ec *Client) proposerDuties(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*proposerDutiesResult, error) {
+	var params epochParameters
+	if err := decodeCallParameters(parameters, &params); err != nil {
+		return nil, err
+	}
+
+	epoch, err := ec.dutiesEpoch(ctx, params.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ec.dutiesClient.GetProposerDuties(ctx, &ethv1.ProposerDutiesRequest{Epoch: epoch})
+	if status.Code(err) == codes.Unimplemented {
+		return ec.assignedProposerDuties(ctx, epoch)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get proposer duties of epoch %d", err, epoch)
+	}
+
+	duties := []*proposerDuty{}
+	slots := []uint64{}
+	for _, duty := range res.Data {
+		slots = append(slots, duty.Slot)
+		duties = append(duties, &proposerDuty{
+			Slot:           strconv.FormatUint(duty.Slot, 10),
+			ValidatorIndex: strconv.FormatUint(duty.ValidatorIndex, 10),
+			PublicKey:      ValidatorAddress(duty.Pubkey),
+		})
+	}
+
+	sort.Sort(&dutiesBySlot{duties: duties, slots: slots})
+	return &proposerDutiesResult{
+		Epoch:  strconv.FormatUint(epoch, 10),
+		Duties: duties,
+	}, nil
+}
+
+// assignedProposerDuties returns the proposer duties
+// of epoch among the assignments of all validators.
+func (ec *Client) assignedProposerDuties(ctx context.Context, epoch uint64) (*proposerDutiesResult, error) {
+	assignments, err := ec.validatorAssignments(ctx, &pb.ListValidatorAssignmentsRequest{
+		QueryFilter: &pb.ListValidatorAssignmentsRequest_Epoch{Epoch: epoch},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	duties := []*proposerDuty{}
+	slots := []uint64{}
+	for _, assignment := range assignments {
+		for _, slot := range assignment.ProposerSlots {
+			pubkey, err := ec.validators.Pubkey(ctx, assignment.ValidatorIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			slots = append(slots, slot)
+			duties = append(duties, &proposerDuty{
+				Slot:           strconv.FormatUint(slot, 10),
+				ValidatorIndex: strconv.FormatUint(assignment.ValidatorIndex, 10),
+				PublicKey:      ValidatorAddress(pubkey),
+			})
+		}
+	}
+
+	sort.Sort(&dutiesBySlot{duties: duties, slots: slots})
+	return &proposerDutiesResult{
+		Epoch:  strconv.FormatUint(epoch, 10),
+		Duties: duties,
+	}, nil
+}
+
+// dutiesBySlot sorts proposer duties by slot.
+type dutiesBySlot struct {
+	duties []*proposerDuty
+	slots  []uint64
+}
+
+func (d *dutiesBySlot) Len() int           { return len(d.duties) }
+func (d *dutiesBySlot) Less(i, j int) bool { return d.slots[i] < d.slots[j] }
+func (d *dutiesBySlot) Swap(i, j int) {
+	d.duties[i], d.duties[j] = d.duties[j], d.duties[i]
+	d.slots[i], d.slots[j] = d.slots[j], d.slots[i]
+}
+
+// validatorDutiesParameters are the
+// parameters of validator_duties.
+type validatorDutiesParameters struct {
+	Validators []string `json:"validators"`
+	Epoch      string   `json:"epoch"`
+}
+
+// validatorDuty is the assignment of a
+// validator of validatorDutiesResult.
+type validatorDuty struct {
+	PublicKey      string   `json:"public_key"`
+	ValidatorIndex string   `json:"validator_index"`
+	AttesterSlot   string   `json:"attester_slot"`
+	CommitteeIndex string   `json:"committee_index"`
+	CommitteeSize  string   `json:"committee_size"`
+	ProposerSlots  []string `json:"proposer_slots"`
+}
+
+// validatorDutiesResult is the result of validator_duties.
+type validatorDutiesResult struct {
+	Epoch  string           `json:"epoch"`
+	Duties []*validatorDuty `json:"duties"`
+}
+
+// validatorDuties implements validator_duties.
+func (ec *Client) validatorDuties(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*validatorDutiesResult, error) {
+	var params validatorDutiesParameters
+	if err := decodeCallParameters(parameters, &params); err != nil {
+		return nil, err
+	}
+	if len(params.Validators) == 0 {
+		return nil, fmt.Errorf("%w: validators are required", ErrCallParametersInvalid)
+	}
+
+	pubkeys := make([][]byte, len(params.Validators))
+	for i, validator := range params.Validators {
+		pubkey, err := ParseValidatorAddress(validator)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCallParametersInvalid, err.Error())
+		}
+		pubkeys[i] = pubkey
+	}
+
+	epoch, err := ec.dutiesEpoch(ctx, params.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := ec.validatorAssignments(ctx, &pb.ListValidatorAssignmentsRequest{
+		QueryFilter: &pb.ListValidatorAssignmentsRequest_Epoch{Epoch: epoch},
+		PublicKeys:  pubkeys,
+	})
+	if grpcCode(err) == codes.NotFound {
+		return nil, fmt.Errorf("%w: %s", ErrCallParametersInvalid, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &validatorDutiesResult{
+		Epoch:  strconv.FormatUint(epoch, 10),
+		Duties: make([]*validatorDuty, len(assignments)),
+	}
+	for i, assignment := range assignments {
+		pubkey, err := ec.validators.Pubkey(ctx, assignment.ValidatorIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		proposerSlots := make([]string, len(assignment.ProposerSlots))
+		for j, slot := range assignment.ProposerSlots {
+			proposerSlots[j] = strconv.FormatUint(slot, 10)
+		}
+
+		result.Duties[i] = &validatorDuty{
+			PublicKey:      ValidatorAddress(pubkey),
+			ValidatorIndex: strconv.FormatUint(assignment.ValidatorIndex, 10),
+			AttesterSlot:   strconv.FormatUint(assignment.AttesterSlot, 10),
+			CommitteeIndex: strconv.FormatUint(assignment.CommitteeIndex, 10),
+			CommitteeSize:  strconv.Itoa(len(assignment.BeaconCommittees)),
+			ProposerSlots:  proposerSlots,
+		}
+	}
+
+	return result, nil
+}
+
+// grpcCode returns the code of the gRPC status wrapped by
+// err at any depth, or codes.Unknown if there is none.
+func grpcCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus().Code()
+	}
+
+	return codes.Unknown
+}
+
+// validatorAssignments returns all pages of the
+// validator assignments matching req.
+func (ec *Client) validatorAssignments(
+	ctx context.Context,
+	req *pb.ListValidatorAssignmentsRequest,
+) ([]*pb.ValidatorAssignments_CommitteeAssignment, error) {
+	assignments := []*pb.ValidatorAssignments_CommitteeAssignment{}
+	req.PageSize = validatorsPageSize
+	for {
+		res, err := ec.beaconChainClient.ListValidatorAssignments(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not list validator assignments", err)
+		}
+
+		assignments = append(assignments, res.Assignments...)
+		if len(res.NextPageToken) == 0 || len(res.Assignments) == 0 {
+			return assignments, nil
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
+
+// dutiesEpoch returns the epoch of the epoch parameter of
+// a duties method, or the epoch of the chain head. Duties
+// are only known dutiesLookahead epochs in advance.
+func (ec *Client) dutiesEpoch(ctx context.Context, epochParameter string) (uint64, error) {
+	chainHead, err := ec.chainHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	headEpoch := chainHead.HeadSlot / ec.network.Preset.SlotsPerEpoch
+	if len(epochParameter) == 0 {
+		return headEpoch, nil
+	}
+
+	epoch, err := parseUintParameter("epoch", epochParameter)
+	if err != nil {
+		return 0, err
+	}
+
+	if epoch > headEpoch+dutiesLookahead {
+		return 0, fmt.Errorf(
+			"%w: duties of epoch %d are not known before epoch %d, the head is at epoch %d",
+			ErrCallParametersInvalid,
+			epoch,
+			epoch-dutiesLookahead,
+			headEpoch,
+		)
+	}
+
+	return epoch, nil
+}
+
+// checkpoint is a checkpoint of finalityCheckpointsResult.
+type checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// finalityCheckpointsResult is the result of finality_checkpoints.
+type finalityCheckpointsResult struct {
+	Finalized         *checkpoint `json:"finalized"`
+	CurrentJustified  *checkpoint `json:"current_justified"`
+	PreviousJustified *checkpoint `json:"previous_justified"`
+}
+
+// finalityCheckpoints implements finality_checkpoints.
+func (ec *Client) finalityCheckpoints(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*finalityCheckpointsResult, error) {
+	if err := decodeCallParameters(parameters, &struct{}{}); err != nil {
+		return nil, err
+	}
+
+	chainHead, err := ec.chainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &finalityCheckpointsResult{
+		Finalized: &checkpoint{
+			Epoch: strconv.FormatUint(chainHead.FinalizedEpoch, 10),
+			Root:  hex.EncodeToString(chainHead.FinalizedBlockRoot),
+		},
+		CurrentJustified: &checkpoint{
+			Epoch: strconv.FormatUint(chainHead.JustifiedEpoch, 10),
+			Root:  hex.EncodeToString(chainHead.JustifiedBlockRoot),
+		},
+		PreviousJustified: &checkpoint{
+			Epoch: strconv.FormatUint(chainHead.PreviousJustifiedEpoch, 10),
+			Root:  hex.EncodeToString(chainHead.PreviousJustifiedBlockRoot),
+		},
+	}, nil
+}
+
+// chainConfigResult is the result of chain_config.
+type chainConfigResult struct {
+	Preset string            `json:"preset"`
+	Config map[string]string `json:"config"`
+}
+
+// chainConfig implements chain_config.
+func (ec *Client) chainConfig(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*chainConfigResult, error) {
+	if err := decodeCallParameters(parameters, &struct{}{}); err != nil {
+		return nil, err
+	}
+
+	config, err := ec.beaconChainClient.GetBeaconConfig(ctx, &types.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get beacon config", err)
+	}
+
+	return &chainConfigResult{
+		Preset: ec.network.Preset.Name,
+		Config: config.Config,
+	}, nil
+}
+
+// epochParameter returns the epoch parameter of
+// a method, or the epoch of the chain head.
+func (ec *Client) epochParameter(ctx context.Context, parameters map[string]interface{}) (uint64, error) {
+	var params epochParameters
+	if err := decodeCallParameters(parameters, &params); err != nil {
+		return 0, err
+	}
+
+	if len(params.Epoch) > 0 {
+		return parseUintParameter("epoch", params.Epoch)
+	}
+
+	chainHead, err := ec.chainHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return chainHead.HeadSlot / ec.network.Preset.SlotsPerEpoch, nil
+}
+
+// decodeCallParameters decodes the parameters of a
+// /call method into v, rejecting unknown parameters.
+func decodeCallParameters(parameters map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(parameters)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrCallParametersInvalid, err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: %s", ErrCallParametersInvalid, err.Error())
+	}
+
+	return nil
+}
+
+// parseUintParameter parses the decimal
+// uint64 parameter name of a /call method.
+func parseUintParameter(name string, value string) (uint64, error) {
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s %q is not a decimal uint64", ErrCallParametersInvalid, name, value)
+	}
+
+	return v, nil
+}
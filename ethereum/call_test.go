@@ -0,0 +1,553 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	types "github.com/gogo/protobuf/types"
+	ethv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCallChainClient serves a chain with head at slot
+// 3200 (epoch 100), or answers with err when set.
+type fakeCallChainClient struct {
+	pb.BeaconChainClient
+
+	err error
+}
+
+func (c *fakeCallChainClient) GetChainHead(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*pb.ChainHead, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &pb.ChainHead{
+		HeadSlot:                   3200,
+		FinalizedEpoch:             98,
+		FinalizedBlockRoot:         []byte{0x98},
+		JustifiedEpoch:             99,
+		JustifiedBlockRoot:         []byte{0x99},
+		PreviousJustifiedEpoch:     98,
+		PreviousJustifiedBlockRoot: []byte{0x98},
+	}, nil
+}
+
+func (c *fakeCallChainClient) GetBeaconConfig(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*pb.BeaconConfig, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &pb.BeaconConfig{Config: map[string]string{"SlotsPerEpoch": "32"}}, nil
+}
+
+func (c *fakeCallChainClient) ListBeaconCommittees(
+	ctx context.Context,
+	req *pb.ListCommitteesRequest,
+	opts ...grpc.CallOption,
+) (*pb.BeaconCommittees, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	epoch := req.GetEpoch()
+	return &pb.BeaconCommittees{
+		Epoch:                epoch,
+		ActiveValidatorCount: 6,
+		Committees: map[uint64]*pb.BeaconCommittees_CommitteesList{
+			epoch*32 + 1: {Committees: []*pb.BeaconCommittees_CommitteeItem{
+				{ValidatorIndices: []uint64{5, 0}},
+			}},
+			epoch * 32: {Committees: []*pb.BeaconCommittees_CommitteeItem{
+				{ValidatorIndices: []uint64{3, 1}},
+				{ValidatorIndices: []uint64{2, 4}},
+			}},
+		},
+	}, nil
+}
+
+func (c *fakeCallChainClient) ListValidatorAssignments(
+	ctx context.Context,
+	req *pb.ListValidatorAssignmentsRequest,
+	opts ...grpc.CallOption,
+) (*pb.ValidatorAssignments, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	epoch := req.GetEpoch()
+	if len(req.PublicKeys) > 0 {
+		res := &pb.ValidatorAssignments{Epoch: epoch}
+		for _, pubkey := range req.PublicKeys {
+			index := binary.BigEndian.Uint64(pubkey[PubkeyLength-8:])
+			if index >= 8 {
+				return nil, status.Errorf(codes.NotFound, "Could not find validator index for public key %#x", pubkey)
+			}
+
+			assignment := &pb.ValidatorAssignments_CommitteeAssignment{
+				ValidatorIndex:   index,
+				AttesterSlot:     epoch*32 + index%2,
+				CommitteeIndex:   index % 2,
+				BeaconCommittees: []uint64{index, (index + 1) % 8},
+			}
+			if index == 3 {
+				assignment.ProposerSlots = []uint64{epoch*32 + 5}
+			}
+			res.Assignments = append(res.Assignments, assignment)
+		}
+		return res, nil
+	}
+
+	if len(req.PageToken) == 0 {
+		return &pb.ValidatorAssignments{
+			Epoch: epoch,
+			Assignments: []*pb.ValidatorAssignments_CommitteeAssignment{
+				{ValidatorIndex: 1, AttesterSlot: epoch * 32},
+				{ValidatorIndex: 3, AttesterSlot: epoch * 32, ProposerSlots: []uint64{epoch*32 + 5}},
+			},
+			NextPageToken: "1",
+		}, nil
+	}
+
+	return &pb.ValidatorAssignments{
+		Epoch: epoch,
+		Assignments: []*pb.ValidatorAssignments_CommitteeAssignment{
+			{ValidatorIndex: 5, AttesterSlot: epoch*32 + 1, ProposerSlots: []uint64{epoch*32 + 1}},
+		},
+	}, nil
+}
+
+type fakeDutiesClient struct {
+	ethv1.BeaconValidatorClient
+
+	err error
+}
+
+func (c *fakeDutiesClient) GetProposerDuties(
+	ctx context.Context,
+	req *ethv1.ProposerDutiesRequest,
+	opts ...grpc.CallOption,
+) (*ethv1.ProposerDutiesResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &ethv1.ProposerDutiesResponse{
+		Data: []*ethv1.ProposerDuty{
+			{Pubkey: testPubkey(3), ValidatorIndex: 3, Slot: req.Epoch*32 + 5},
+			{Pubkey: testPubkey(5), ValidatorIndex: 5, Slot: req.Epoch*32 + 1},
+		},
+	}, nil
+}
+
+func (c *fakeStatusClient) ValidatorStatus(
+	ctx context.Context,
+	req *pb.ValidatorStatusRequest,
+	opts ...grpc.CallOption,
+) (*pb.ValidatorStatusResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &pb.ValidatorStatusResponse{
+		Status:                 c.statuses[string(req.PublicKey)],
+		Eth1DepositBlockNumber: 11200000,
+		DepositInclusionSlot:   120,
+		ActivationEpoch:        FarFutureEpoch,
+	}, nil
+}
+
+func TestGrpcCode(t *testing.T) {
+	notFound := status.Error(codes.NotFound, "validator not found")
+
+	tests := map[string]struct {
+		err error
+
+		expected codes.Code
+	}{
+		"no error":      {err: nil, expected: codes.OK},
+		"status":        {err: notFound, expected: codes.NotFound},
+		"wrapped":       {err: fmt.Errorf("%w: could not list validator assignments", notFound), expected: codes.NotFound},
+		"wrapped twice": {err: fmt.Errorf("%w: page 2", fmt.Errorf("%w: could not list", notFound)), expected: codes.NotFound},
+		"other error":   {err: errors.New("connection reset"), expected: codes.Unknown},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, grpcCode(test.err))
+		})
+	}
+}
+
+func TestCallMethodSpecs(t *testing.T) {
+	assert.Len(t, CallMethodSpecs, len(CallMethods))
+	for _, method := range CallMethods {
+		spec, ok := CallMethodSpecs[method]
+		assert.True(t, ok, method)
+		assert.NotEmpty(t, spec.Description)
+		assert.Equal(t, "object", spec.Parameters.Type)
+		for _, required := range spec.Parameters.Required {
+			assert.Contains(t, spec.Parameters.Properties, required)
+		}
+	}
+}
+
+func TestClient_Call(t *testing.T) {
+	ctx := context.Background()
+	statuses := map[string]pb.ValidatorStatus{
+		string(testPubkey(3)):  pb.ValidatorStatus_ACTIVE,
+		string(testPubkey(12)): pb.ValidatorStatus_DEPOSITED,
+	}
+
+	tests := map[string]struct {
+		method     string
+		parameters map[string]interface{}
+		chainErr   error
+		statusErr  error
+		dutiesErr  error
+
+		expected    map[string]interface{}
+		expectedErr error
+	}{
+		"validator status by index": {
+			method:     ValidatorStatusMethod,
+			parameters: map[string]interface{}{"validator": "3"},
+			expected: map[string]interface{}{
+				"public_key":                   ValidatorAddress(testPubkey(3)),
+				"index":                        "3",
+				"status":                       "ACTIVE",
+				"eth1_deposit_block_number":    "11200000",
+				"deposit_inclusion_slot":       "120",
+				"activation_epoch":             "18446744073709551615",
+				"position_in_activation_queue": "0",
+			},
+		},
+		"validator status of a deposit": {
+			method:     ValidatorStatusMethod,
+			parameters: map[string]interface{}{"validator": ValidatorAddress(testPubkey(12))},
+			expected: map[string]interface{}{
+				"public_key":                   ValidatorAddress(testPubkey(12)),
+				"status":                       "DEPOSITED",
+				"eth1_deposit_block_number":    "11200000",
+				"deposit_inclusion_slot":       "120",
+				"activation_epoch":             "18446744073709551615",
+				"position_in_activation_queue": "0",
+			},
+		},
+		"validator status of an unknown index": {
+			method:      ValidatorStatusMethod,
+			parameters:  map[string]interface{}{"validator": "9"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator status of an invalid validator": {
+			method:      ValidatorStatusMethod,
+			parameters:  map[string]interface{}{"validator": "0xa0"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator status without validator": {
+			method:      ValidatorStatusMethod,
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator status with a numeric validator": {
+			method:      ValidatorStatusMethod,
+			parameters:  map[string]interface{}{"validator": 3},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator status error": {
+			method:      ValidatorStatusMethod,
+			parameters:  map[string]interface{}{"validator": "3"},
+			statusErr:   status.Error(codes.Unavailable, "syncing"),
+			expectedErr: status.Error(codes.Unavailable, "syncing"),
+		},
+		"committees of the head epoch": {
+			method: CommitteesMethod,
+			expected: map[string]interface{}{
+				"epoch":                  "100",
+				"active_validator_count": "6",
+				"committees": []interface{}{
+					map[string]interface{}{"slot": "3200", "index": "0", "validators": []interface{}{"3", "1"}},
+					map[string]interface{}{"slot": "3200", "index": "1", "validators": []interface{}{"2", "4"}},
+					map[string]interface{}{"slot": "3201", "index": "0", "validators": []interface{}{"5", "0"}},
+				},
+			},
+		},
+		"committees of an epoch": {
+			method:     CommitteesMethod,
+			parameters: map[string]interface{}{"epoch": "2"},
+			expected: map[string]interface{}{
+				"epoch":                  "2",
+				"active_validator_count": "6",
+				"committees": []interface{}{
+					map[string]interface{}{"slot": "64", "index": "0", "validators": []interface{}{"3", "1"}},
+					map[string]interface{}{"slot": "64", "index": "1", "validators": []interface{}{"2", "4"}},
+					map[string]interface{}{"slot": "65", "index": "0", "validators": []interface{}{"5", "0"}},
+				},
+			},
+		},
+		"committees of an invalid epoch": {
+			method:      CommitteesMethod,
+			parameters:  map[string]interface{}{"epoch": "-1"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"committees with an unknown parameter": {
+			method:      CommitteesMethod,
+			parameters:  map[string]interface{}{"slot": "64"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"proposer duties": {
+			method:     ProposerDutiesMethod,
+			parameters: map[string]interface{}{"epoch": "2"},
+			expected: map[string]interface{}{
+				"epoch": "2",
+				"duties": []interface{}{
+					map[string]interface{}{
+						"slot":            "65",
+						"validator_index": "5",
+						"public_key":      ValidatorAddress(testPubkey(5)),
+					},
+					map[string]interface{}{
+						"slot":            "69",
+						"validator_index": "3",
+						"public_key":      ValidatorAddress(testPubkey(3)),
+					},
+				},
+			},
+		},
+		"proposer duties without the eth/v1 API": {
+			method:     ProposerDutiesMethod,
+			parameters: map[string]interface{}{"epoch": "2"},
+			dutiesErr:  status.Error(codes.Unimplemented, "unknown service ethereum.eth.v1.BeaconValidator"),
+			expected: map[string]interface{}{
+				"epoch": "2",
+				"duties": []interface{}{
+					map[string]interface{}{
+						"slot":            "65",
+						"validator_index": "5",
+						"public_key":      ValidatorAddress(testPubkey(5)),
+					},
+					map[string]interface{}{
+						"slot":            "69",
+						"validator_index": "3",
+						"public_key":      ValidatorAddress(testPubkey(3)),
+					},
+				},
+			},
+		},
+		"proposer duties error": {
+			method:      ProposerDutiesMethod,
+			dutiesErr:   status.Error(codes.InvalidArgument, "cannot retrieve information about an epoch in the future"),
+			expectedErr: status.Error(codes.InvalidArgument, "cannot retrieve information about an epoch in the future"),
+		},
+		"proposer duties of the next epoch": {
+			method:     ProposerDutiesMethod,
+			parameters: map[string]interface{}{"epoch": "101"},
+			expected: map[string]interface{}{
+				"epoch": "101",
+				"duties": []interface{}{
+					map[string]interface{}{
+						"slot":            "3233",
+						"validator_index": "5",
+						"public_key":      ValidatorAddress(testPubkey(5)),
+					},
+					map[string]interface{}{
+						"slot":            "3237",
+						"validator_index": "3",
+						"public_key":      ValidatorAddress(testPubkey(3)),
+					},
+				},
+			},
+		},
+		"proposer duties too far in the future": {
+			method:      ProposerDutiesMethod,
+			parameters:  map[string]interface{}{"epoch": "102"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator duties": {
+			method: ValidatorDutiesMethod,
+			parameters: map[string]interface{}{
+				"validators": []interface{}{ValidatorAddress(testPubkey(3)), ValidatorAddress(testPubkey(6))},
+				"epoch":      "101",
+			},
+			expected: map[string]interface{}{
+				"epoch": "101",
+				"duties": []interface{}{
+					map[string]interface{}{
+						"public_key":      ValidatorAddress(testPubkey(3)),
+						"validator_index": "3",
+						"attester_slot":   "3233",
+						"committee_index": "1",
+						"committee_size":  "2",
+						"proposer_slots":  []interface{}{"3237"},
+					},
+					map[string]interface{}{
+						"public_key":      ValidatorAddress(testPubkey(6)),
+						"validator_index": "6",
+						"attester_slot":   "3232",
+						"committee_index": "0",
+						"committee_size":  "2",
+						"proposer_slots":  []interface{}{},
+					},
+				},
+			},
+		},
+		"validator duties of the head epoch": {
+			method: ValidatorDutiesMethod,
+			parameters: map[string]interface{}{
+				"validators": []interface{}{ValidatorAddress(testPubkey(6))},
+			},
+			expected: map[string]interface{}{
+				"epoch": "100",
+				"duties": []interface{}{
+					map[string]interface{}{
+						"public_key":      ValidatorAddress(testPubkey(6)),
+						"validator_index": "6",
+						"attester_slot":   "3200",
+						"committee_index": "0",
+						"committee_size":  "2",
+						"proposer_slots":  []interface{}{},
+					},
+				},
+			},
+		},
+		"validator duties too far in the future": {
+			method: ValidatorDutiesMethod,
+			parameters: map[string]interface{}{
+				"validators": []interface{}{ValidatorAddress(testPubkey(3))},
+				"epoch":      "102",
+			},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator duties of an unknown validator": {
+			method: ValidatorDutiesMethod,
+			parameters: map[string]interface{}{
+				"validators": []interface{}{ValidatorAddress(testPubkey(12))},
+			},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator duties of an index": {
+			method: ValidatorDutiesMethod,
+			parameters: map[string]interface{}{
+				"validators": []interface{}{"3"},
+			},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"validator duties without validators": {
+			method:      ValidatorDutiesMethod,
+			parameters:  map[string]interface{}{"epoch": "100"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"finality checkpoints": {
+			method: FinalityCheckpointsMethod,
+			expected: map[string]interface{}{
+				"finalized":          map[string]interface{}{"epoch": "98", "root": "98"},
+				"current_justified":  map[string]interface{}{"epoch": "99", "root": "99"},
+				"previous_justified": map[string]interface{}{"epoch": "98", "root": "98"},
+			},
+		},
+		"finality checkpoints with parameters": {
+			method:      FinalityCheckpointsMethod,
+			parameters:  map[string]interface{}{"epoch": "2"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"chain config": {
+			method: ChainConfigMethod,
+			expected: map[string]interface{}{
+				"preset": MainnetPreset,
+				"config": map[string]interface{}{"SlotsPerEpoch": "32"},
+			},
+		},
+		"participation of the previous epoch": {
+			method: ParticipationMethod,
+			expected: map[string]interface{}{
+				"epoch":                     "99",
+				"finalized":                 false,
+				"global_participation_rate": 0.75,
+				"voted_ether":               "144000000000",
+				"eligible_ether":            "192000000000",
+				"active_validator_count":    "6",
+				"total_active_balance":      "224000000000",
+				"validator_queue": map[string]interface{}{
+					"churn_limit":             "4",
+					"activation_queue_length": "2",
+					"exit_queue_length":       "0",
+				},
+			},
+		},
+		"participation of an epoch": {
+			method:     ParticipationMethod,
+			parameters: map[string]interface{}{"epoch": "98"},
+			expected: map[string]interface{}{
+				"epoch":                     "98",
+				"finalized":                 true,
+				"global_participation_rate": 0.75,
+				"voted_ether":               "144000000000",
+				"eligible_ether":            "192000000000",
+				"active_validator_count":    "6",
+				"total_active_balance":      "224000000000",
+				"validator_queue": map[string]interface{}{
+					"churn_limit":             "4",
+					"activation_queue_length": "2",
+					"exit_queue_length":       "0",
+				},
+			},
+		},
+		"participation of the head epoch": {
+			method:      ParticipationMethod,
+			parameters:  map[string]interface{}{"epoch": "100"},
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"participation error": {
+			method:      ParticipationMethod,
+			chainErr:    errors.New("connection refused"),
+			expectedErr: errors.New("connection refused"),
+		},
+		"unknown method": {
+			method:      "eth_call",
+			expectedErr: ErrCallMethodInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &Client{
+				network:           MainnetNetworkConfig,
+				beaconChainClient: &fakeCallChainClient{err: test.chainErr},
+				validatorClient:   &fakeStatusClient{statuses: statuses, err: test.statusErr},
+				dutiesClient:      &fakeDutiesClient{err: test.dutiesErr},
+				validators:        NewValidatorRegistry(&fakeRegistryClient{size: 8}, MainnetNetworkConfig, DefaultValidatorRegistrySize),
+			}
+
+			response, err := client.Call(ctx, &RosettaTypes.CallRequest{
+				Method:     test.method,
+				Parameters: test.parameters,
+			})
+			if test.expectedErr != nil {
+				assert.Contains(t, err.Error(), test.expectedErr.Error())
+				if errors.Is(test.expectedErr, ErrCallParametersInvalid) ||
+					errors.Is(test.expectedErr, ErrCallMethodInvalid) {
+					assert.True(t, errors.Is(err, test.expectedErr))
+				}
+				assert.Nil(t, response)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &RosettaTypes.CallResponse{Result: test.expected}, response)
+			}
+		})
+	}
+}
@@ -1,41 +1,86 @@
 package ethereum
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"rosetta-ethereum-2.0/timeutils"
+	"rosetta-ethereum-2.0/tracing"
 
 	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
 	types "github.com/gogo/protobuf/types"
+	ethv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
 	grpc "google.golang.org/grpc"
 )
 
 const (
-	secondsPerSlotCreation = uint64(12)
-	grpcTimeout            = 120 * time.Second
+	grpcTimeout = 120 * time.Second
+	tracerName  = "rosetta-ethereum-2.0/ethereum"
 )
 
+var tracer = tracing.Tracer(tracerName)
+
 // Client allows for querying a set of specific Ethereum 2.0 endpoints in an
 // idempotent manner.
 //
 type Client struct {
 	url               string
+	network           *NetworkConfig
 	nodeClient        pb.NodeClient
 	beaconChainClient pb.BeaconChainClient
+	validatorClient   pb.BeaconNodeValidatorClient
+	poolClient        ethv1.BeaconChainClient
+	dutiesClient      ethv1.BeaconValidatorClient
 	conn              *grpc.ClientConn
+	execution         executionClient
+	validators        *ValidatorRegistry
+
+	// participationMetadata adds the participation of the
+	// previous epoch to the first block of every epoch.
+	participationMetadata bool
+
+	// genesisBlock caches the identifier of the slot 0
+	// block when it is not pinned in the network config.
+	genesisBlock     *RosettaTypes.BlockIdentifier
+	genesisBlockLock sync.Mutex
 }
 
-func NewClient(ctx context.Context, url string) (*Client, error) {
-	conn, err := grpc.DialContext(ctx, url, grpc.WithInsecure())
+// NewClient creates a Client querying the beacon node at url.
+// Deposits are funded through the web3 provider at
+// web3Provider, which may be empty.
+func NewClient(
+	ctx context.Context,
+	url string,
+	web3Provider string,
+	network *NetworkConfig,
+) (*Client, error) {
+	conn, err := grpc.DialContext(
+		ctx,
+		url,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	)
 	if err != nil {
-		log.Fatalf("did not connect to remote wallet: %s", err)
+		return nil, fmt.Errorf("%w: unable to dial beacon node", err)
+	}
+
+	execution, err := dialExecution(ctx, web3Provider)
+	if err != nil {
+		conn.Close()
+		return nil, err
 	}
 
 	bcc := pb.NewBeaconChainClient(conn)
@@ -43,15 +88,39 @@ func NewClient(ctx context.Context, url string) (*Client, error) {
 
 	return &Client{
 		url:               url,
+		network:           network,
 		nodeClient:        nc,
 		beaconChainClient: bcc,
+		validatorClient:   pb.NewBeaconNodeValidatorClient(conn),
+		poolClient:        ethv1.NewBeaconChainClient(conn),
+		dutiesClient:      ethv1.NewBeaconValidatorClient(conn),
 		conn:              conn,
+		execution:         execution,
+		validators:        NewValidatorRegistry(bcc, network, DefaultValidatorRegistrySize),
 	}, nil
 }
 
-// Close shuts down the RPC client connection.
+// EnableParticipationMetadata adds the validator participation
+// of the previous epoch to the metadata of the first block of
+// every epoch, at the cost of two more beacon node queries.
+func (ec *Client) EnableParticipationMetadata() {
+	ec.participationMetadata = true
+}
+
+// WarmValidatorRegistry starts loading the validator
+// registry in the background, so that the first requests
+// resolving validators are not served from the beacon
+// node one validator at a time.
+func (ec *Client) WarmValidatorRegistry() {
+	ec.validators.Warm()
+}
+
+// Close shuts down the RPC client connections.
 func (ec *Client) Close() {
 	ec.conn.Close()
+	if ec.execution != nil {
+		ec.execution.Close()
+	}
 }
 
 func (ec *Client) Status(ctx context.Context) (
@@ -62,6 +131,9 @@ func (ec *Client) Status(ctx context.Context) (
 	[]*RosettaTypes.Peer,
 	error,
 ) {
+	ctx, span := tracer.Start(ctx, "Client.Status")
+	defer span.End()
+
 	chainHead, err := ec.chainHead(ctx)
 	if err != nil {
 		return nil, nil, -1, nil, nil, err
@@ -73,7 +145,7 @@ func (ec *Client) Status(ctx context.Context) (
 	}
 
 	genesisTime := genesis.GetGenesisTime()
-	highestBlock := getHighestBlock(genesisTime.GetSeconds())
+	highestBlock := getHighestBlock(genesisTime.GetSeconds(), ec.network.Preset.SecondsPerSlot)
 
 	var syncStatus *RosettaTypes.SyncStatus
 	currentIndex := int64(chainHead.GetHeadSlot())
@@ -89,12 +161,13 @@ func (ec *Client) Status(ctx context.Context) (
 		synced = false
 	}
 
-	fmt.Println("[DEBUG] [STATUS] {")
-	fmt.Println("[DEBUG] [STATUS]     syncStatus: ", stage)
-	fmt.Println("[DEBUG] [STATUS]     currentIndex: ", currentIndex)
-	fmt.Println("[DEBUG] [STATUS]     targetIndex: ", targetIndex)
-	fmt.Println("[DEBUG] [STATUS]     timestamp: ", timeutils.Now().Unix()*1000)
-	fmt.Println("[DEBUG] [STATUS] }")
+	log.Printf(
+		"level=debug msg=status network=%s stage=%q current_index=%d target_index=%d",
+		ec.network.Name,
+		stage,
+		currentIndex,
+		targetIndex,
+	)
 
 	syncStatus = &RosettaTypes.SyncStatus{
 		CurrentIndex: &currentIndex,
@@ -108,14 +181,18 @@ func (ec *Client) Status(ctx context.Context) (
 		return nil, nil, -1, nil, nil, err
 	}
 
+	// A beacon node that has not stored the genesis block
+	// yet reports no genesis rather than failing.
+	genesisBlock, err := ec.genesisBlockIdentifier(ctx)
+	if err != nil && !errors.Is(err, ErrGenesisBlockNotFound) {
+		return nil, nil, -1, nil, nil, err
+	}
+
 	return &RosettaTypes.BlockIdentifier{
 			Hash:  hex.EncodeToString(chainHead.GetHeadBlockRoot()),
 			Index: int64(chainHead.GetHeadSlot()),
 		},
-		&RosettaTypes.BlockIdentifier{
-			Hash:  hex.EncodeToString(genesis.GetGenesisValidatorsRoot()),
-			Index: 1,
-		},
+		genesisBlock,
 		timeutils.Now().Unix() * 1000,
 		syncStatus,
 		peers,
@@ -125,7 +202,7 @@ func (ec *Client) Status(ctx context.Context) (
 func (ec *Client) chainHead(ctx context.Context) (*pb.ChainHead, error) {
 	res, err := ec.beaconChainClient.GetChainHead(ctx, &types.Empty{})
 	if err != nil {
-		log.Fatalf("could not get chain head: %s", err)
+		return nil, fmt.Errorf("%w: could not get chain head", err)
 	}
 	return res, nil
 }
@@ -134,7 +211,7 @@ func (ec *Client) chainHead(ctx context.Context) (*pb.ChainHead, error) {
 func (ec *Client) peers(ctx context.Context) ([]*RosettaTypes.Peer, error) {
 	res, err := ec.nodeClient.ListPeers(ctx, &types.Empty{})
 	if err != nil {
-		log.Fatalf("could not list peers: %s", err)
+		return nil, fmt.Errorf("%w: could not list peers", err)
 	}
 	info := res.GetPeers()
 
@@ -158,18 +235,67 @@ func (ec *Client) peers(ctx context.Context) ([]*RosettaTypes.Peer, error) {
 func (ec *Client) genesis(ctx context.Context) (*pb.Genesis, error) {
 	genesis, err := ec.nodeClient.GetGenesis(ctx, &types.Empty{})
 	if err != nil {
-		log.Fatalf("could not retrieve genesis: %s", err)
+		return nil, fmt.Errorf("%w: could not retrieve genesis", err)
 	}
 
 	return genesis, nil
 }
 
+// ValidatorPubkey returns the public key of
+// the validator at index.
+func (ec *Client) ValidatorPubkey(ctx context.Context, index uint64) ([]byte, error) {
+	return ec.validators.Pubkey(ctx, index)
+}
+
+// ValidatorIndex returns the index of the
+// validator with the public key pubkey.
+func (ec *Client) ValidatorIndex(ctx context.Context, pubkey []byte) (uint64, error) {
+	return ec.validators.Index(ctx, pubkey)
+}
+
+// genesisBlockIdentifier returns the identifier of the slot 0
+// block. It is taken from the network config when pinned and is
+// otherwise fetched from the beacon node once.
+func (ec *Client) genesisBlockIdentifier(ctx context.Context) (*RosettaTypes.BlockIdentifier, error) {
+	if ec.network.GenesisBlockIdentifier != nil {
+		return ec.network.GenesisBlockIdentifier, nil
+	}
+
+	ec.genesisBlockLock.Lock()
+	defer ec.genesisBlockLock.Unlock()
+
+	if ec.genesisBlock != nil {
+		return ec.genesisBlock, nil
+	}
+
+	res, err := ec.beaconChainClient.ListBlocks(ctx, &pb.ListBlocksRequest{
+		QueryFilter: &pb.ListBlocksRequest_Slot{Slot: 0},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get genesis block", err)
+	}
+	if len(res.BlockContainers) < 1 {
+		return nil, ErrGenesisBlockNotFound
+	}
+
+	ec.genesisBlock = &RosettaTypes.BlockIdentifier{
+		Index: 0,
+		Hash:  hex.EncodeToString(res.BlockContainers[0].BlockRoot),
+	}
+
+	return ec.genesisBlock, nil
+}
+
 func (ec *Client) Block(
 	ctx context.Context,
 	blockIdentifier *RosettaTypes.PartialBlockIdentifier,
 ) (*RosettaTypes.Block, error) {
+	ctx, span := tracer.Start(ctx, "Client.Block")
+	defer span.End()
+
 	if blockIdentifier != nil {
 		if blockIdentifier.Hash != nil {
+			span.SetAttributes(attribute.String("block.hash", *blockIdentifier.Hash))
 			res, err := ec.blockByHash(ctx, *blockIdentifier.Hash)
 			if err != nil {
 				return nil, err
@@ -178,6 +304,7 @@ func (ec *Client) Block(
 		}
 
 		if blockIdentifier.Index != nil {
+			span.SetAttributes(attribute.Int64("block.index", *blockIdentifier.Index))
 			res, err := ec.blockByIndex(ctx, *blockIdentifier.Index)
 			if err != nil {
 				return nil, err
@@ -197,7 +324,7 @@ func (ec *Client) blockByIndex(ctx context.Context, block int64) (*pb.ListBlocks
 
 	res, err := ec.beaconChainClient.ListBlocks(ctx, in)
 	if err != nil {
-		log.Fatalf("could not get block by slot index: %s", err)
+		return nil, fmt.Errorf("%w: could not get block by slot index", err)
 	}
 	if len(res.BlockContainers) < 1 {
 		chainHead, err := ec.chainHead(ctx)
@@ -216,7 +343,7 @@ func (ec *Client) blockByHash(ctx context.Context, rawHash string) (*pb.ListBloc
 	hash := trimHash(rawHash)
 	h, err := hex.DecodeString(hash)
 	if err != nil {
-		log.Fatalf("could not decode hash: %s", err)
+		return nil, fmt.Errorf("%w: could not decode hash", err)
 	}
 
 	in := &pb.ListBlocksRequest{
@@ -225,7 +352,7 @@ func (ec *Client) blockByHash(ctx context.Context, rawHash string) (*pb.ListBloc
 
 	res, err := ec.beaconChainClient.ListBlocks(ctx, in)
 	if err != nil {
-		log.Fatalf("could not get block by root hash: %s", err)
+		return nil, fmt.Errorf("%w: could not get block by root hash", err)
 	}
 	if len(res.BlockContainers) < 1 {
 		return nil, ErrBlockNotFound
@@ -239,14 +366,32 @@ func (ec *Client) parseBeaconBlock(ctx context.Context, block *pb.ListBlocksResp
 	}
 	b := block.BlockContainers[0]
 
-	var parentBlockIdentifier *RosettaTypes.BlockIdentifier
-	parentBlocks, err := ec.blockByHash(ctx, hex.EncodeToString(b.Block.Block.ParentRoot))
-	if err != nil {
-		return nil, err
+	blockIdentifier := &RosettaTypes.BlockIdentifier{
+		Index: int64(b.Block.Block.Slot),
+		Hash:  hex.EncodeToString(b.BlockRoot),
 	}
-	parentBlock := parentBlocks.BlockContainers[0]
 
-	if b.Block.Block.Slot != 0 {
+	// The genesis block has no parent, so it is
+	// its own parent as required by Rosetta.
+	parentBlockIdentifier := blockIdentifier
+	if b.Block.Block.Slot == 0 {
+		genesisBlock := ec.network.GenesisBlockIdentifier
+		if genesisBlock != nil && genesisBlock.Hash != blockIdentifier.Hash {
+			return nil, fmt.Errorf(
+				"%w: beacon node reports %s but %s expects %s",
+				ErrGenesisBlockMismatch,
+				blockIdentifier.Hash,
+				ec.network.Name,
+				genesisBlock.Hash,
+			)
+		}
+	} else {
+		parentBlocks, err := ec.blockByHash(ctx, hex.EncodeToString(b.Block.Block.ParentRoot))
+		if err != nil {
+			return nil, err
+		}
+		parentBlock := parentBlocks.BlockContainers[0]
+
 		parentBlockIdentifier = &RosettaTypes.BlockIdentifier{
 			Index: int64(parentBlock.Block.Block.Slot),
 			Hash:  hex.EncodeToString(parentBlock.BlockRoot),
@@ -258,42 +403,99 @@ func (ec *Client) parseBeaconBlock(ctx context.Context, block *pb.ListBlocksResp
 		return nil, err
 	}
 
-	fmt.Println("[DEBUG] [BLOCK] {")
-	fmt.Println("[DEBUG] [BLOCK]     currentBlock: ", int64(b.Block.Block.Slot))
-	fmt.Println("[DEBUG] [BLOCK]     currentHash: ", hex.EncodeToString(b.BlockRoot))
-	fmt.Println("[DEBUG] [BLOCK]     parentBlock: ", int64(parentBlock.Block.Block.Slot))
-	fmt.Println("[DEBUG] [BLOCK]     parentHash: ", hex.EncodeToString(parentBlock.BlockRoot))
-	fmt.Println("[DEBUG] [BLOCK]     timestamp: ", timestamp)
-	fmt.Println("[DEBUG] [BLOCK] }")
+	transactions, err := ec.voluntaryExitTransactions(ctx, b.Block.Block.GetBody().GetVoluntaryExits())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := ec.blockMetadata(ctx, b.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	participation, err := ec.boundaryParticipation(ctx, b.Block.Block.Slot, uint64(parentBlockIdentifier.Index))
+	if err != nil {
+		return nil, err
+	}
+	if participation != nil {
+		metadata["participation"] = participation
+	}
+
+	log.Printf(
+		"level=debug msg=block network=%s index=%d hash=%s parent_index=%d parent_hash=%s timestamp=%d",
+		ec.network.Name,
+		blockIdentifier.Index,
+		blockIdentifier.Hash,
+		parentBlockIdentifier.Index,
+		parentBlockIdentifier.Hash,
+		timestamp,
+	)
 
 	return &RosettaTypes.Block{
-		BlockIdentifier: &RosettaTypes.BlockIdentifier{
-			Index: int64(b.Block.Block.Slot),
-			Hash:  hex.EncodeToString(b.BlockRoot),
-		},
+		BlockIdentifier:       blockIdentifier,
 		ParentBlockIdentifier: parentBlockIdentifier,
 		//The timestamp in milliseconds because some blockchains produce block more often than once a second.
 		Timestamp:    timestamp * 1000,
-		Transactions: nil,
-		Metadata: map[string]interface{}{
-			"epoch": int64(b.Block.Block.Slot) / 32,
-			// "attestations": b.Block.Block.Body,
+		Transactions: transactions,
+		Metadata:     metadata,
+	}, nil
+}
+
+// blockMetadata returns the metadata of block: its
+// epoch, its proposer and the fields of its header
+// and body that are not operations.
+func (ec *Client) blockMetadata(
+	ctx context.Context,
+	block *pb.SignedBeaconBlock,
+) (map[string]interface{}, error) {
+	proposerIndex := block.Block.ProposerIndex
+	proposerPubkey, err := ec.validators.Pubkey(ctx, proposerIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	body := block.Block.GetBody()
+	eth1Data := body.GetEth1Data()
+	return map[string]interface{}{
+		"epoch":           int64(block.Block.Slot) / int64(ec.network.Preset.SlotsPerEpoch),
+		"proposer_index":  strconv.FormatUint(proposerIndex, 10),
+		"proposer_pubkey": ValidatorAddress(proposerPubkey),
+		"graffiti":        graffitiText(body.GetGraffiti()),
+		"graffiti_hex":    hex.EncodeToString(body.GetGraffiti()),
+		"randao_reveal":   hex.EncodeToString(body.GetRandaoReveal()),
+		"eth1_data": map[string]interface{}{
+			"deposit_root":  hex.EncodeToString(eth1Data.GetDepositRoot()),
+			"deposit_count": strconv.FormatUint(eth1Data.GetDepositCount(), 10),
+			"block_hash":    hex.EncodeToString(eth1Data.GetBlockHash()),
 		},
+		"state_root": hex.EncodeToString(block.Block.StateRoot),
+		"signature":  hex.EncodeToString(block.Signature),
 	}, nil
 }
 
-func getHighestBlock(genesisTimeSec int64) uint64 {
+// graffitiText returns graffiti without its zero padding
+// when it is valid UTF-8, and hex-encoded otherwise.
+func graffitiText(graffiti []byte) string {
+	text := bytes.TrimRight(graffiti, "\x00")
+	if !utf8.Valid(text) {
+		return hex.EncodeToString(graffiti)
+	}
+
+	return string(text)
+}
+
+func getHighestBlock(genesisTimeSec int64, secondsPerSlot uint64) uint64 {
 	now := timeutils.Now().Unix()
 	genesis := int64(genesisTimeSec)
 	if now < genesis {
 		return 0
 	}
-	return uint64(now-genesis) / secondsPerSlotCreation
+	return uint64(now-genesis) / secondsPerSlot
 }
 
-func getHighestFinalizedBlock(highestBlock uint64) uint64 {
-	r := (highestBlock / 32) - 2
-	return r * 32
+func getHighestFinalizedBlock(highestBlock uint64, slotsPerEpoch uint64) uint64 {
+	r := (highestBlock / slotsPerEpoch) - 2
+	return r * slotsPerEpoch
 }
 
 func (ec *Client) getBlockTimestamp(ctx context.Context, blockNumber int64) (int64, error) {
@@ -303,7 +505,8 @@ func (ec *Client) getBlockTimestamp(ctx context.Context, blockNumber int64) (int
 	}
 	genesisTime := genesis.GetGenesisTime()
 
-	return (12 * blockNumber) + int64(genesisTime.GetSeconds()), nil
+	secondsPerSlot := int64(ec.network.Preset.SecondsPerSlot)
+	return (secondsPerSlot * blockNumber) + int64(genesisTime.GetSeconds()), nil
 
 }
 
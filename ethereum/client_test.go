@@ -0,0 +1,368 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	grpc "google.golang.org/grpc"
+)
+
+type fakeNodeServer struct {
+	pb.UnimplementedNodeServer
+}
+
+func (s *fakeNodeServer) GetGenesis(context.Context, *types.Empty) (*pb.Genesis, error) {
+	return &pb.Genesis{
+		GenesisTime:           &types.Timestamp{Seconds: 1606824023},
+		GenesisValidatorsRoot: mainnetGenesisValidatorsRoot,
+	}, nil
+}
+
+func (s *fakeNodeServer) ListPeers(context.Context, *types.Empty) (*pb.Peers, error) {
+	return &pb.Peers{}, nil
+}
+
+// mainnetGenesisValidatorsRoot is reported by fakeNodeServer.
+var mainnetGenesisValidatorsRoot, _ = hex.DecodeString(MainnetNetworkConfig.GenesisValidatorsRoot)
+
+type fakeBeaconChainServer struct {
+	pb.UnimplementedBeaconChainServer
+
+	// blocks are served by ListBlocks.
+	blocks []*pb.BeaconBlockContainer
+
+	// genesisQueries counts the ListBlocks
+	// requests for slot 0.
+	genesisQueries int
+}
+
+func (s *fakeBeaconChainServer) ListBlocks(
+	ctx context.Context,
+	req *pb.ListBlocksRequest,
+) (*pb.ListBlocksResponse, error) {
+	if filter, ok := req.QueryFilter.(*pb.ListBlocksRequest_Slot); ok && filter.Slot == 0 {
+		s.genesisQueries++
+	}
+
+	containers := []*pb.BeaconBlockContainer{}
+	for _, block := range s.blocks {
+		switch filter := req.QueryFilter.(type) {
+		case *pb.ListBlocksRequest_Slot:
+			if block.Block.Block.Slot == filter.Slot {
+				containers = append(containers, block)
+			}
+		case *pb.ListBlocksRequest_Root:
+			if bytes.Equal(block.BlockRoot, filter.Root) {
+				containers = append(containers, block)
+			}
+		}
+	}
+
+	return &pb.ListBlocksResponse{BlockContainers: containers}, nil
+}
+
+// ListValidators serves a registry of 8 validators,
+// proposing the blocks of fakeBlock.
+func (s *fakeBeaconChainServer) ListValidators(
+	ctx context.Context,
+	req *pb.ListValidatorsRequest,
+) (*pb.Validators, error) {
+	return (&fakeRegistryClient{size: 8}).ListValidators(ctx, req)
+}
+
+// GetValidator serves the validators of ListValidators
+// while the registry is loading.
+func (s *fakeBeaconChainServer) GetValidator(
+	ctx context.Context,
+	req *pb.GetValidatorRequest,
+) (*pb.Validator, error) {
+	return (&fakeRegistryClient{size: 8}).GetValidator(ctx, req)
+}
+
+func (s *fakeBeaconChainServer) GetChainHead(context.Context, *types.Empty) (*pb.ChainHead, error) {
+	return &pb.ChainHead{HeadSlot: 10, HeadBlockRoot: []byte{0x0a}}, nil
+}
+
+func (s *fakeBeaconChainServer) GetBeaconConfig(context.Context, *types.Empty) (*pb.BeaconConfig, error) {
+	return &pb.BeaconConfig{Config: map[string]string{
+		"DepositContractAddress": "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		"GenesisForkVersion":     "[0 0 16 32]",
+		"SlotsPerEpoch":          "32",
+	}}, nil
+}
+
+// startFakeBeacon serves the fake beacon node
+// on a random local port.
+func startFakeBeacon(t *testing.T, beaconChain *fakeBeaconChainServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	s := grpc.NewServer()
+	pb.RegisterNodeServer(s, &fakeNodeServer{})
+	pb.RegisterBeaconChainServer(s, beaconChain)
+	go s.Serve(lis) // nolint
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestClient_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", MainnetNetworkConfig)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	_, _, _, _, _, err = client.Status(ctx)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	var status sdktrace.ReadOnlySpan
+	rpcs := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range spans {
+		if span.Name() == "Client.Status" {
+			status = span
+			continue
+		}
+		rpcs[span.Name()] = span
+	}
+
+	assert.NotNil(t, status)
+	for _, name := range []string{
+		"ethereum.eth.v1alpha1.BeaconChain/GetChainHead",
+		"ethereum.eth.v1alpha1.Node/GetGenesis",
+		"ethereum.eth.v1alpha1.Node/ListPeers",
+	} {
+		span, ok := rpcs[name]
+		assert.True(t, ok, name)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, status.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+}
+
+// fakeBlock returns a block container at slot
+// whose root and parent root are derived from
+// the slot.
+func fakeBlock(slot uint64, parentSlot uint64) *pb.BeaconBlockContainer {
+	root := func(slot uint64) []byte {
+		r := make([]byte, rootLength)
+		r[0] = 0xbb
+		r[rootLength-1] = byte(slot)
+		return r
+	}
+
+	parentRoot := root(parentSlot)
+	if slot == 0 {
+		parentRoot = make([]byte, rootLength)
+	}
+
+	return &pb.BeaconBlockContainer{
+		Block: &pb.SignedBeaconBlock{
+			Block: &pb.BeaconBlock{
+				Slot:       slot,
+				ParentRoot: parentRoot,
+			},
+		},
+		BlockRoot: root(slot),
+	}
+}
+
+func TestClient_GenesisBlock(t *testing.T) {
+	ctx := context.Background()
+	genesisHash := "bb" + strings.Repeat("00", rootLength-1)
+	devnet := &NetworkConfig{
+		Name:        "devnet",
+		Preset:      Presets[MinimalPreset],
+		GenesisTime: 1606824023,
+	}
+
+	t.Run("fetched from the beacon node once", func(t *testing.T) {
+		beaconChain := &fakeBeaconChainServer{
+			blocks: []*pb.BeaconBlockContainer{fakeBlock(0, 0), fakeBlock(1, 0)},
+		}
+		client, err := NewClient(ctx, startFakeBeacon(t, beaconChain), "", devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		_, genesisBlock, _, _, _, err := client.Status(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, &RosettaTypes.BlockIdentifier{Index: 0, Hash: genesisHash}, genesisBlock)
+
+		_, genesisBlockAgain, _, _, _, err := client.Status(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, genesisBlock, genesisBlockAgain)
+		assert.Equal(t, 1, beaconChain.genesisQueries)
+
+		// /block for index 0 must agree with /network/status
+		// and be its own parent.
+		block, err := client.Block(ctx, &RosettaTypes.PartialBlockIdentifier{
+			Index: RosettaTypes.Int64(0),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, genesisBlock, block.BlockIdentifier)
+		assert.Equal(t, genesisBlock, block.ParentBlockIdentifier)
+
+		block, err = client.Block(ctx, &RosettaTypes.PartialBlockIdentifier{
+			Index: RosettaTypes.Int64(1),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, genesisBlock, block.ParentBlockIdentifier)
+	})
+
+	t.Run("not stored by the beacon node", func(t *testing.T) {
+		beaconChain := &fakeBeaconChainServer{}
+		client, err := NewClient(ctx, startFakeBeacon(t, beaconChain), "", devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		_, genesisBlock, _, _, _, err := client.Status(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, genesisBlock)
+	})
+
+	t.Run("pinned in the network config", func(t *testing.T) {
+		pinned := *devnet
+		pinned.GenesisBlockIdentifier = &RosettaTypes.BlockIdentifier{Index: 0, Hash: genesisHash}
+		beaconChain := &fakeBeaconChainServer{
+			blocks: []*pb.BeaconBlockContainer{fakeBlock(0, 0)},
+		}
+		client, err := NewClient(ctx, startFakeBeacon(t, beaconChain), "", &pinned)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		_, genesisBlock, _, _, _, err := client.Status(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, pinned.GenesisBlockIdentifier, genesisBlock)
+		assert.Equal(t, 0, beaconChain.genesisQueries)
+
+		block, err := client.Block(ctx, &RosettaTypes.PartialBlockIdentifier{
+			Index: RosettaTypes.Int64(0),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, genesisBlock, block.BlockIdentifier)
+	})
+
+	t.Run("pinned genesis block mismatch", func(t *testing.T) {
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{
+			blocks: []*pb.BeaconBlockContainer{fakeBlock(0, 0)},
+		}), "", MainnetNetworkConfig)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		block, err := client.Block(ctx, &RosettaTypes.PartialBlockIdentifier{
+			Index: RosettaTypes.Int64(0),
+		})
+		assert.Nil(t, block)
+		assert.True(t, errors.Is(err, ErrGenesisBlockMismatch))
+	})
+}
+
+func TestClient_BlockMetadata(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{
+		network:    MainnetNetworkConfig,
+		validators: NewValidatorRegistry(&fakeRegistryClient{size: 8}, MainnetNetworkConfig, DefaultValidatorRegistrySize),
+	}
+
+	graffiti := make([]byte, rootLength)
+	copy(graffiti, "Lighthouse/v1.0.3")
+	block := &pb.SignedBeaconBlock{
+		Block: &pb.BeaconBlock{
+			Slot:          3205,
+			ProposerIndex: 5,
+			StateRoot:     bytes.Repeat([]byte{0x5a}, rootLength),
+			Body: &pb.BeaconBlockBody{
+				RandaoReveal: bytes.Repeat([]byte{0xaa}, SignatureLength),
+				Eth1Data: &pb.Eth1Data{
+					DepositRoot:  bytes.Repeat([]byte{0xd0}, rootLength),
+					DepositCount: 21073,
+					BlockHash:    bytes.Repeat([]byte{0xe1}, rootLength),
+				},
+				Graffiti: graffiti,
+			},
+		},
+		Signature: bytes.Repeat([]byte{0xbb}, SignatureLength),
+	}
+
+	metadata, err := client.blockMetadata(ctx, block)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"epoch":           int64(100),
+		"proposer_index":  "5",
+		"proposer_pubkey": ValidatorAddress(testPubkey(5)),
+		"graffiti":        "Lighthouse/v1.0.3",
+		"graffiti_hex":    hex.EncodeToString(graffiti),
+		"randao_reveal":   strings.Repeat("aa", SignatureLength),
+		"eth1_data": map[string]interface{}{
+			"deposit_root":  strings.Repeat("d0", rootLength),
+			"deposit_count": "21073",
+			"block_hash":    strings.Repeat("e1", rootLength),
+		},
+		"state_root": strings.Repeat("5a", rootLength),
+		"signature":  strings.Repeat("bb", SignatureLength),
+	}, metadata)
+
+	t.Run("unknown proposer", func(t *testing.T) {
+		block.Block.ProposerIndex = 9
+
+		metadata, err := client.blockMetadata(ctx, block)
+		assert.Nil(t, metadata)
+		assert.True(t, errors.Is(err, ErrValidatorNotFound))
+	})
+}
+
+func TestGraffitiText(t *testing.T) {
+	padded := func(graffiti string) []byte {
+		b := make([]byte, rootLength)
+		copy(b, graffiti)
+		return b
+	}
+
+	tests := map[string]struct {
+		graffiti []byte
+
+		expected string
+	}{
+		"ascii": {
+			graffiti: padded("poapAAAAAAAAAAAA"),
+			expected: "poapAAAAAAAAAAAA",
+		},
+		"utf-8": {
+			graffiti: padded("🦏 rosetta"),
+			expected: "🦏 rosetta",
+		},
+		"empty": {
+			graffiti: make([]byte, rootLength),
+			expected: "",
+		},
+		"invalid utf-8": {
+			graffiti: padded("\xff\xfe"),
+			expected: "fffe" + strings.Repeat("00", rootLength-2),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, graffitiText(test.graffiti))
+		})
+	}
+}
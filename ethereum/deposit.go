@@ -0,0 +1,111 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	// MinDepositAmount is the smallest amount in
+	// Gwei accepted by the deposit contract.
+	MinDepositAmount = 1000000000
+
+	// DepositGasLimit is the gas limit of deposit contract
+	// calls, which use less than 100k gas. Unused gas is
+	// refunded.
+	DepositGasLimit = 100000
+
+	// depositContractAddressKey is the beacon config entry
+	// holding the address of the deposit contract.
+	depositContractAddressKey = "DepositContractAddress"
+
+	// depositContractABI is the ABI of the deposit
+	// function and DepositEvent of the deposit contract.
+	depositContractABI = `[{"name":"deposit","type":"function","stateMutability":"payable",` +
+		`"inputs":[{"name":"pubkey","type":"bytes"},{"name":"withdrawal_credentials","type":"bytes"},` +
+		`{"name":"signature","type":"bytes"},{"name":"deposit_data_root","type":"bytes32"}],"outputs":[]},` +
+		`{"name":"DepositEvent","type":"event","anonymous":false,` +
+		`"inputs":[{"name":"pubkey","type":"bytes","indexed":false},` +
+		`{"name":"withdrawal_credentials","type":"bytes","indexed":false},` +
+		`{"name":"amount","type":"bytes","indexed":false},` +
+		`{"name":"signature","type":"bytes","indexed":false},` +
+		`{"name":"index","type":"bytes","indexed":false}]}]`
+)
+
+var (
+	// ErrDepositAmountInvalid is returned when a deposit amount
+	// is not a whole number of Gwei of at least MinDepositAmount.
+	ErrDepositAmountInvalid = errors.New("deposit amount invalid")
+
+	depositContract = mustParseABI(depositContractABI)
+)
+
+// DepositAmount converts a deposit amount in wei to
+// the amount in Gwei recorded in the deposit data.
+func DepositAmount(wei *big.Int) (uint64, error) {
+	gwei, remainder := new(big.Int).QuoRem(wei, big.NewInt(gweiToWei), new(big.Int))
+	if remainder.Sign() != 0 {
+		return 0, fmt.Errorf("%w: %s wei is not a whole number of Gwei", ErrDepositAmountInvalid, wei)
+	}
+
+	if gwei.Cmp(big.NewInt(MinDepositAmount)) < 0 || gwei.Cmp(new(big.Int).SetUint64(math.MaxUint64)) > 0 {
+		return 0, fmt.Errorf("%w: %s Gwei is out of range", ErrDepositAmountInvalid, gwei)
+	}
+
+	return gwei.Uint64(), nil
+}
+
+// DepositContract returns the address of the deposit
+// contract the beacon node processes deposits from.
+func (ec *Client) DepositContract(ctx context.Context) (common.Address, error) {
+	ctx, span := tracer.Start(ctx, "Client.DepositContract")
+	defer span.End()
+
+	config, err := ec.beaconConfig(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	address := config[depositContractAddressKey]
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf(
+			"%w: %s is %q",
+			ErrBeaconConfigInvalid,
+			depositContractAddressKey,
+			address,
+		)
+	}
+
+	return common.HexToAddress(address), nil
+}
+
+// DepositInput returns the input of the deposit
+// contract call depositing data.
+func DepositInput(data *pb.Deposit_Data) ([]byte, error) {
+	root := DepositDataRoot(data)
+	return depositContract.Pack(
+		"deposit",
+		data.PublicKey,
+		data.WithdrawalCredentials,
+		data.Signature,
+		root,
+	)
+}
+
+// mustParseABI parses a contract ABI known to be valid.
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
@@ -0,0 +1,94 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepositAmount(t *testing.T) {
+	tests := map[string]struct {
+		wei string
+
+		expected    uint64
+		expectedErr error
+	}{
+		"full deposit": {
+			wei:      "32000000000000000000",
+			expected: 32000000000,
+		},
+		"minimum deposit": {
+			wei:      "1000000000000000000",
+			expected: MinDepositAmount,
+		},
+		"below minimum": {
+			wei:         "999999999000000000",
+			expectedErr: ErrDepositAmountInvalid,
+		},
+		"fraction of a Gwei": {
+			wei:         "32000000000000000001",
+			expectedErr: ErrDepositAmountInvalid,
+		},
+		"overflows Gwei": {
+			wei:         "18446744073709551616000000000",
+			expectedErr: ErrDepositAmountInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			wei, _ := new(big.Int).SetString(test.wei, 10)
+			gwei, err := DepositAmount(wei)
+			if test.expectedErr != nil {
+				assert.True(t, errors.Is(err, test.expectedErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, gwei)
+			assert.Equal(t, wei, Wei(gwei))
+		})
+	}
+}
+
+func TestDepositInput(t *testing.T) {
+	data := &pb.Deposit_Data{
+		PublicKey:             testPubkey(7),
+		WithdrawalCredentials: testCredentials(2),
+		Amount:                32000000000,
+		Signature:             bytes.Repeat([]byte{0xa1}, SignatureLength),
+	}
+
+	input, err := DepositInput(data)
+	assert.NoError(t, err)
+
+	// deposit(bytes,bytes,bytes,bytes32)
+	assert.Equal(t, []byte{0x22, 0x89, 0x51, 0x18}, input[:4])
+
+	args, err := depositContract.Methods["deposit"].Inputs.Unpack(input[4:])
+	assert.NoError(t, err)
+	root := DepositDataRoot(data)
+	assert.Equal(t, []interface{}{
+		data.PublicKey,
+		data.WithdrawalCredentials,
+		data.Signature,
+		root,
+	}, args)
+}
+
+func TestClient_DepositContract(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", MainnetNetworkConfig)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	contract, err := client.DepositContract(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"), contract)
+}
@@ -10,4 +10,7 @@ var (
 	ErrCallMethodInvalid     = errors.New("call method invalid")
 	ErrBlockNotFound         = errors.New("block not found")
 	ErrBlockMissed           = errors.New("block is missed")
+	ErrGenesisBlockNotFound  = errors.New("genesis block not found")
+	ErrGenesisBlockMismatch  = errors.New("genesis block mismatch")
+	ErrTransactionNotFound   = errors.New("transaction not found")
 )
@@ -0,0 +1,103 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var (
+	// ErrWeb3ProviderUnavailable is returned when an execution
+	// layer request is made for a network without a web3
+	// provider.
+	ErrWeb3ProviderUnavailable = errors.New("web3 provider unavailable")
+)
+
+// executionClient is the subset of the ethclient.Client
+// used to fund deposits and follow the deposit contract.
+type executionClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error)
+	FilterLogs(ctx context.Context, q geth.FilterQuery) ([]gethTypes.Log, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *gethTypes.Transaction) error
+	Close()
+}
+
+// TransactionParams are the parameters of an execution
+// layer transaction that are read from the chain.
+type TransactionParams struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	ChainID  *big.Int
+}
+
+// dialExecution connects to the web3 provider at url,
+// or returns nil if url is empty.
+func dialExecution(ctx context.Context, url string) (executionClient, error) {
+	if len(url) == 0 {
+		return nil, nil
+	}
+
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to dial web3 provider", err)
+	}
+
+	return client, nil
+}
+
+// TransactionParams returns the parameters of the
+// next transaction sent by from.
+func (ec *Client) TransactionParams(ctx context.Context, from common.Address) (*TransactionParams, error) {
+	ctx, span := tracer.Start(ctx, "Client.TransactionParams")
+	defer span.End()
+
+	if ec.execution == nil {
+		return nil, ErrWeb3ProviderUnavailable
+	}
+
+	nonce, err := ec.execution.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get nonce", err)
+	}
+
+	gasPrice, err := ec.execution.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get gas price", err)
+	}
+
+	chainID, err := ec.execution.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get chain id", err)
+	}
+
+	return &TransactionParams{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		ChainID:  chainID,
+	}, nil
+}
+
+// SendTransaction broadcasts tx through the web3 provider.
+func (ec *Client) SendTransaction(ctx context.Context, tx *gethTypes.Transaction) error {
+	ctx, span := tracer.Start(ctx, "Client.SendTransaction")
+	defer span.End()
+
+	if ec.execution == nil {
+		return ErrWeb3ProviderUnavailable
+	}
+
+	if err := ec.execution.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("%w: could not send transaction", err)
+	}
+
+	return nil
+}
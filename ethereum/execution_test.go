@@ -0,0 +1,108 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExecutionClient serves a chain on which every account
+// has sent nonce transactions, with head and deposit logs.
+type fakeExecutionClient struct {
+	nonce uint64
+	head  *gethTypes.Header
+	logs  []gethTypes.Log
+	err   error
+
+	sent    []*gethTypes.Transaction
+	queries []geth.FilterQuery
+}
+
+func (c *fakeExecutionClient) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(1), c.err
+}
+
+func (c *fakeExecutionClient) HeaderByNumber(context.Context, *big.Int) (*gethTypes.Header, error) {
+	return c.head, c.err
+}
+
+func (c *fakeExecutionClient) FilterLogs(ctx context.Context, q geth.FilterQuery) ([]gethTypes.Log, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.queries = append(c.queries, q)
+	return c.logs, nil
+}
+
+func (c *fakeExecutionClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return c.nonce, c.err
+}
+
+func (c *fakeExecutionClient) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(20000000000), c.err
+}
+
+func (c *fakeExecutionClient) SendTransaction(ctx context.Context, tx *gethTypes.Transaction) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *fakeExecutionClient) Close() {}
+
+func TestClient_TransactionParams(t *testing.T) {
+	ctx := context.Background()
+	from := common.HexToAddress("0x57f75C3e5b3C8A8fE0eaF23a7Dc1cAd0F1BcF6C8")
+
+	t.Run("web3 provider", func(t *testing.T) {
+		client := &Client{execution: &fakeExecutionClient{nonce: 5}}
+
+		params, err := client.TransactionParams(ctx, from)
+		assert.NoError(t, err)
+		assert.Equal(t, &TransactionParams{
+			Nonce:    5,
+			GasPrice: big.NewInt(20000000000),
+			ChainID:  big.NewInt(1),
+		}, params)
+	})
+
+	t.Run("web3 provider error", func(t *testing.T) {
+		rpcErr := errors.New("connection refused")
+		client := &Client{execution: &fakeExecutionClient{err: rpcErr}}
+
+		params, err := client.TransactionParams(ctx, from)
+		assert.Nil(t, params)
+		assert.True(t, errors.Is(err, rpcErr))
+	})
+
+	t.Run("no web3 provider", func(t *testing.T) {
+		client := &Client{}
+
+		params, err := client.TransactionParams(ctx, from)
+		assert.Nil(t, params)
+		assert.True(t, errors.Is(err, ErrWeb3ProviderUnavailable))
+	})
+}
+
+func TestClient_SendTransaction(t *testing.T) {
+	ctx := context.Background()
+	tx := gethTypes.NewTransaction(0, common.Address{}, big.NewInt(1), DepositGasLimit, big.NewInt(1), nil)
+
+	execution := &fakeExecutionClient{}
+	client := &Client{execution: execution}
+	assert.NoError(t, client.SendTransaction(ctx, tx))
+	assert.Equal(t, []*gethTypes.Transaction{tx}, execution.sent)
+
+	client = &Client{}
+	assert.True(t, errors.Is(client.SendTransaction(ctx, tx), ErrWeb3ProviderUnavailable))
+}
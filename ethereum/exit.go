@@ -0,0 +1,129 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Messages of the exit verification errors
+// returned by prysm's ProposeExit.
+const (
+	alreadyExitedMsg   = "has already submitted an exit"
+	cannotExitYetMsg   = "validator has not been active long enough to exit"
+	exitEpochMsg       = "expected current epoch >= exit epoch"
+	notActiveMsg       = "non-active validator cannot exit"
+	signatureFailedMsg = "signature did not verify"
+	signatureLengthMsg = "invalid signature provided"
+	unknownIndexMsg    = "validator index exceeds validator set length"
+)
+
+var (
+	// ErrValidatorAlreadyExiting is returned when an exit is
+	// submitted for a validator that already initiated an exit.
+	ErrValidatorAlreadyExiting = errors.New("validator already exiting")
+
+	// ErrExitTooEarly is returned when an exit is submitted before
+	// its epoch or before the validator has been active long enough.
+	ErrExitTooEarly = errors.New("exit too early")
+
+	// ErrValidatorNotActive is returned when an exit
+	// is submitted for a validator that is not active.
+	ErrValidatorNotActive = errors.New("validator not active")
+
+	// ErrExitSignatureInvalid is returned when the
+	// signature of a submitted exit does not verify.
+	ErrExitSignatureInvalid = errors.New("exit signature invalid")
+
+	// ErrExitRejected is returned when the beacon node
+	// rejects an exit for any other reason.
+	ErrExitRejected = errors.New("exit rejected")
+)
+
+// SubmitVoluntaryExit broadcasts exit and returns its
+// hash tree root, which identifies it in blocks.
+func (ec *Client) SubmitVoluntaryExit(ctx context.Context, exit *pb.SignedVoluntaryExit) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "Client.SubmitVoluntaryExit")
+	defer span.End()
+
+	res, err := ec.validatorClient.ProposeExit(ctx, exit)
+	if err != nil {
+		return nil, exitErr(err)
+	}
+
+	return res.ExitRoot, nil
+}
+
+// exitErr maps a ProposeExit error to the error
+// of the reason the exit was rejected for.
+func exitErr(err error) error {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.InvalidArgument {
+		return fmt.Errorf("%w: could not propose exit", err)
+	}
+
+	msg := s.Message()
+	switch {
+	case strings.Contains(msg, alreadyExitedMsg):
+		return fmt.Errorf("%w: %s", ErrValidatorAlreadyExiting, msg)
+	case strings.Contains(msg, cannotExitYetMsg), strings.Contains(msg, exitEpochMsg):
+		return fmt.Errorf("%w: %s", ErrExitTooEarly, msg)
+	case strings.Contains(msg, notActiveMsg):
+		return fmt.Errorf("%w: %s", ErrValidatorNotActive, msg)
+	case strings.Contains(msg, signatureFailedMsg), strings.Contains(msg, signatureLengthMsg):
+		return fmt.Errorf("%w: %s", ErrExitSignatureInvalid, msg)
+	case strings.Contains(msg, unknownIndexMsg):
+		return fmt.Errorf("%w: %s", ErrValidatorNotFound, msg)
+	default:
+		return fmt.Errorf("%w: %s", ErrExitRejected, msg)
+	}
+}
+
+// voluntaryExitTransactions returns the transactions of the
+// voluntary exits included in a block. They are identified
+// by the hash tree root of the exit, as in
+// /construction/hash.
+func (ec *Client) voluntaryExitTransactions(
+	ctx context.Context,
+	exits []*pb.SignedVoluntaryExit,
+) ([]*RosettaTypes.Transaction, error) {
+	transactions := make([]*RosettaTypes.Transaction, len(exits))
+	for i, exit := range exits {
+		pubkey, err := ec.validators.Pubkey(ctx, exit.Exit.ValidatorIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		root := VoluntaryExitRoot(exit.Exit)
+		transactions[i] = &RosettaTypes.Transaction{
+			TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
+				Hash: operationHash(root),
+			},
+			Operations: []*RosettaTypes.Operation{
+				{
+					OperationIdentifier: &RosettaTypes.OperationIdentifier{
+						Index: 0,
+					},
+					Type:   VoluntaryExitOpType,
+					Status: RosettaTypes.String(SuccessStatus),
+					Account: &RosettaTypes.AccountIdentifier{
+						Address: ValidatorAddress(pubkey),
+					},
+					Metadata: map[string]interface{}{
+						"validator_index": strconv.FormatUint(exit.Exit.ValidatorIndex, 10),
+						"epoch":           strconv.FormatUint(exit.Exit.Epoch, 10),
+					},
+				},
+			},
+		}
+	}
+
+	return transactions, nil
+}
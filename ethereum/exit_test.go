@@ -0,0 +1,163 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeValidatorClient answers ProposeExit
+// like prysm, or with err when set.
+type fakeValidatorClient struct {
+	pb.BeaconNodeValidatorClient
+
+	err   error
+	exits []*pb.SignedVoluntaryExit
+}
+
+func (c *fakeValidatorClient) ProposeExit(
+	ctx context.Context,
+	req *pb.SignedVoluntaryExit,
+	opts ...grpc.CallOption,
+) (*pb.ProposeExitResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.exits = append(c.exits, req)
+	root := VoluntaryExitRoot(req.Exit)
+	return &pb.ProposeExitResponse{ExitRoot: root[:]}, nil
+}
+
+func TestClient_SubmitVoluntaryExit(t *testing.T) {
+	exit := &pb.SignedVoluntaryExit{
+		Exit:      &pb.VoluntaryExit{Epoch: 194048, ValidatorIndex: 1234},
+		Signature: make([]byte, SignatureLength),
+	}
+
+	tests := map[string]struct {
+		err error
+
+		expectedErr error
+	}{
+		"accepted": {},
+		"already exiting": {
+			err: status.Error(codes.InvalidArgument, "validator with index 1234 "+
+				"has already submitted an exit, which will take place at epoch: 200000"),
+			expectedErr: ErrValidatorAlreadyExiting,
+		},
+		"exit epoch in the future": {
+			err: status.Error(
+				codes.InvalidArgument,
+				"expected current epoch >= exit epoch, received 100 < 194048",
+			),
+			expectedErr: ErrExitTooEarly,
+		},
+		"not active long enough": {
+			err: status.Error(codes.InvalidArgument, "validator has not been active long "+
+				"enough to exit: 300 epochs vs required 356 epochs"),
+			expectedErr: ErrExitTooEarly,
+		},
+		"not active": {
+			err:         status.Error(codes.InvalidArgument, "non-active validator cannot exit"),
+			expectedErr: ErrValidatorNotActive,
+		},
+		"invalid signature": {
+			err:         status.Error(codes.InvalidArgument, "signature did not verify"),
+			expectedErr: ErrExitSignatureInvalid,
+		},
+		"unknown validator": {
+			err:         status.Error(codes.InvalidArgument, "validator index exceeds validator set length"),
+			expectedErr: ErrValidatorNotFound,
+		},
+		"other rejection": {
+			err:         status.Error(codes.InvalidArgument, "nil exit"),
+			expectedErr: ErrExitRejected,
+		},
+		"beacon node error": {
+			err:         status.Error(codes.Internal, "could not get head state"),
+			expectedErr: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			validatorClient := &fakeValidatorClient{err: test.err}
+			client := &Client{
+				network:         MainnetNetworkConfig,
+				validatorClient: validatorClient,
+			}
+
+			root, err := client.SubmitVoluntaryExit(context.Background(), exit)
+			if test.err == nil {
+				assert.NoError(t, err)
+				expected := VoluntaryExitRoot(exit.Exit)
+				assert.Equal(t, expected[:], root)
+				assert.Equal(t, []*pb.SignedVoluntaryExit{exit}, validatorClient.exits)
+				return
+			}
+
+			assert.Nil(t, root)
+			assert.Error(t, err)
+			for _, sentinel := range []error{
+				ErrValidatorAlreadyExiting,
+				ErrExitTooEarly,
+				ErrValidatorNotActive,
+				ErrExitSignatureInvalid,
+				ErrValidatorNotFound,
+				ErrExitRejected,
+			} {
+				assert.Equal(t, sentinel == test.expectedErr, errors.Is(err, sentinel), sentinel.Error())
+			}
+		})
+	}
+}
+
+func TestClient_VoluntaryExitTransactions(t *testing.T) {
+	registryClient := &fakeRegistryClient{size: 8}
+	client := &Client{
+		network:    MainnetNetworkConfig,
+		validators: NewValidatorRegistry(registryClient, MainnetNetworkConfig, DefaultValidatorRegistrySize),
+	}
+
+	exit := &pb.VoluntaryExit{Epoch: 194048, ValidatorIndex: 5}
+	transactions, err := client.voluntaryExitTransactions(context.Background(), []*pb.SignedVoluntaryExit{
+		{Exit: exit, Signature: make([]byte, SignatureLength)},
+	})
+	assert.NoError(t, err)
+
+	root := VoluntaryExitRoot(exit)
+	assert.Equal(t, []*RosettaTypes.Transaction{
+		{
+			TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
+				Hash: hex.EncodeToString(root[:]),
+			},
+			Operations: []*RosettaTypes.Operation{
+				{
+					OperationIdentifier: &RosettaTypes.OperationIdentifier{Index: 0},
+					Type:                VoluntaryExitOpType,
+					Status:              RosettaTypes.String(SuccessStatus),
+					Account: &RosettaTypes.AccountIdentifier{
+						Address: ValidatorAddress(testPubkey(5)),
+					},
+					Metadata: map[string]interface{}{
+						"validator_index": "5",
+						"epoch":           "194048",
+					},
+				},
+			},
+		},
+	}, transactions)
+
+	transactions, err = client.voluntaryExitTransactions(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+}
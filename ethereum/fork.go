@@ -0,0 +1,177 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	types "github.com/gogo/protobuf/types"
+	ethv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// genesisForkVersionKey is the beacon config
+	// entry holding the genesis fork version.
+	genesisForkVersionKey = "GenesisForkVersion"
+)
+
+var (
+	// ErrBeaconConfigInvalid is returned when the beacon
+	// config reported by the beacon node cannot be parsed.
+	ErrBeaconConfigInvalid = errors.New("beacon config invalid")
+
+	// ErrGenesisValidatorsRootMismatch is returned when the beacon
+	// node reports another genesis validators root than the one
+	// configured for the network.
+	ErrGenesisValidatorsRootMismatch = errors.New("genesis validators root mismatch")
+)
+
+// Fork is the data needed to compute
+// signature domains at an epoch.
+type Fork struct {
+	Epoch                 uint64
+	CurrentVersion        [ForkVersionLength]byte
+	GenesisVersion        [ForkVersionLength]byte
+	GenesisValidatorsRoot [rootLength]byte
+}
+
+// Fork returns the Fork at the head of the chain.
+//
+// The current fork version is the one the beacon node reports
+// for its head state, which must match the fork schedule of the
+// network. Beacon nodes without the eth/v1 API fall back to the
+// fork schedule, or to their genesis fork version for networks
+// without one.
+func (ec *Client) Fork(ctx context.Context) (*Fork, error) {
+	ctx, span := tracer.Start(ctx, "Client.Fork")
+	defer span.End()
+
+	chainHead, err := ec.chainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis, err := ec.genesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(genesis.GenesisValidatorsRoot) != rootLength {
+		return nil, fmt.Errorf(
+			"%w: %x",
+			ErrGenesisValidatorsRootInvalid,
+			genesis.GenesisValidatorsRoot,
+		)
+	}
+
+	root := hex.EncodeToString(genesis.GenesisValidatorsRoot)
+	if expected := trimHash(ec.network.GenesisValidatorsRoot); len(expected) > 0 && expected != root {
+		return nil, fmt.Errorf(
+			"%w: beacon node reports %s but %s expects %s",
+			ErrGenesisValidatorsRootMismatch,
+			root,
+			ec.network.Name,
+			expected,
+		)
+	}
+
+	config, err := ec.beaconConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fork := &Fork{
+		Epoch: chainHead.HeadSlot / ec.network.Preset.SlotsPerEpoch,
+	}
+	copy(fork.GenesisValidatorsRoot[:], genesis.GenesisValidatorsRoot)
+
+	version, err := parseConfigBytes(config[genesisForkVersionKey])
+	if err != nil || len(version) != ForkVersionLength {
+		return nil, fmt.Errorf(
+			"%w: %s is %q",
+			ErrBeaconConfigInvalid,
+			genesisForkVersionKey,
+			config[genesisForkVersionKey],
+		)
+	}
+	copy(fork.GenesisVersion[:], version)
+	fork.CurrentVersion = fork.GenesisVersion
+
+	if schedule := ec.network.ForkSchedule; len(schedule) > 0 {
+		if expected := schedule.GenesisVersion(); expected != fork.GenesisVersion {
+			return nil, fmt.Errorf(
+				"%w: beacon node reports %x but %s expects %x",
+				ErrGenesisForkVersionMismatch,
+				fork.GenesisVersion,
+				ec.network.Name,
+				expected,
+			)
+		}
+		fork.CurrentVersion = schedule.VersionAt(fork.Epoch)
+	}
+
+	res, err := ec.poolClient.GetStateFork(ctx, &ethv1.StateRequest{StateId: []byte("head")})
+	if status.Code(err) == codes.Unimplemented {
+		return fork, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get head fork", err)
+	}
+
+	current := res.GetFork().GetCurrentVersion()
+	if len(current) != ForkVersionLength {
+		return nil, fmt.Errorf("%w: head fork version is %x", ErrBeaconConfigInvalid, current)
+	}
+
+	var headVersion [ForkVersionLength]byte
+	copy(headVersion[:], current)
+	if len(ec.network.ForkSchedule) > 0 && headVersion != fork.CurrentVersion {
+		return nil, fmt.Errorf(
+			"%w: beacon node reports %x at epoch %d but %s expects %x",
+			ErrForkVersionMismatch,
+			headVersion,
+			fork.Epoch,
+			ec.network.Name,
+			fork.CurrentVersion,
+		)
+	}
+	fork.CurrentVersion = headVersion
+
+	return fork, nil
+}
+
+// beaconConfig returns the chain config
+// of the beacon node.
+func (ec *Client) beaconConfig(ctx context.Context) (map[string]string, error) {
+	config, err := ec.beaconChainClient.GetBeaconConfig(ctx, &types.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get beacon config", err)
+	}
+
+	return config.Config, nil
+}
+
+// parseConfigBytes parses a byte slice of the beacon
+// config, which prysm formats as "[0 1 2 3]".
+func parseConfigBytes(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, ErrBeaconConfigInvalid
+	}
+
+	fields := strings.Fields(value[1 : len(value)-1])
+	b := make([]byte, len(fields))
+	for i, field := range fields {
+		v, err := strconv.ParseUint(field, 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		b[i] = byte(v)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,166 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrForkScheduleInvalid is returned when a fork schedule
+	// does not start at genesis or is not ordered by epoch.
+	ErrForkScheduleInvalid = errors.New("fork schedule invalid")
+
+	// ErrGenesisForkVersionMismatch is returned when the beacon
+	// node reports another genesis fork version than the one
+	// scheduled for the network.
+	ErrGenesisForkVersionMismatch = errors.New("genesis fork version mismatch")
+
+	// ErrForkVersionMismatch is returned when the beacon node
+	// reports another current fork version than the one
+	// scheduled for the network at the head epoch.
+	ErrForkVersionMismatch = errors.New("fork version mismatch")
+)
+
+const (
+	// Phase0Fork is the name of the genesis fork.
+	Phase0Fork = "phase0"
+
+	// AltairFork is the name of the Altair upgrade.
+	AltairFork = "altair"
+
+	// BellatrixFork is the name of the Bellatrix upgrade.
+	BellatrixFork = "bellatrix"
+
+	// CapellaFork is the name of the Capella upgrade.
+	CapellaFork = "capella"
+
+	// DenebFork is the name of the Deneb upgrade.
+	DenebFork = "deneb"
+)
+
+// ScheduledFork is a fork version activated at an epoch.
+// Name is the consensus upgrade of the fork, which may be
+// empty for forks of custom networks.
+type ScheduledFork struct {
+	Epoch   uint64
+	Version [ForkVersionLength]byte
+	Name    string
+}
+
+// ForkSchedule lists the forks of a network by
+// epoch, starting with its genesis fork.
+type ForkSchedule []*ScheduledFork
+
+var (
+	// MainnetForkSchedule is the
+	// ForkSchedule of Mainnet.
+	MainnetForkSchedule = ForkSchedule{
+		{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x00}, Name: Phase0Fork},
+		{Epoch: 74240, Version: [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x00}, Name: AltairFork},
+		{Epoch: 144896, Version: [ForkVersionLength]byte{0x02, 0x00, 0x00, 0x00}, Name: BellatrixFork},
+		{Epoch: 194048, Version: [ForkVersionLength]byte{0x03, 0x00, 0x00, 0x00}, Name: CapellaFork},
+		{Epoch: 269568, Version: [ForkVersionLength]byte{0x04, 0x00, 0x00, 0x00}, Name: DenebFork},
+	}
+
+	// TestnetForkSchedule is the ForkSchedule of Pyrmont,
+	// which was deprecated before the Bellatrix upgrade.
+	TestnetForkSchedule = ForkSchedule{
+		{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x20, 0x09}, Name: Phase0Fork},
+		{Epoch: 61650, Version: [ForkVersionLength]byte{0x01, 0x00, 0x20, 0x09}, Name: AltairFork},
+	}
+)
+
+// GenesisForkSchedule returns the ForkSchedule of a network
+// that has not forked since genesis, or nil if version is
+// empty. version is a 0x-prefixed or bare hex string.
+func GenesisForkSchedule(version string) (ForkSchedule, error) {
+	if len(version) == 0 {
+		return nil, nil
+	}
+
+	genesisVersion, err := ParseForkVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return ForkSchedule{{Version: genesisVersion}}, nil
+}
+
+// ParseForkVersion parses a 0x-prefixed
+// or bare hex-encoded fork version.
+func ParseForkVersion(version string) ([ForkVersionLength]byte, error) {
+	var parsed [ForkVersionLength]byte
+	b, err := hex.DecodeString(trimHash(version))
+	if err != nil || len(b) != ForkVersionLength {
+		return parsed, fmt.Errorf("%w: fork version %s", ErrForkScheduleInvalid, version)
+	}
+
+	copy(parsed[:], b)
+	return parsed, nil
+}
+
+// Validate returns an error unless the schedule starts
+// at genesis and its epochs are strictly increasing.
+func (s ForkSchedule) Validate() error {
+	if len(s) == 0 || s[0].Epoch != 0 {
+		return fmt.Errorf("%w: no genesis fork", ErrForkScheduleInvalid)
+	}
+
+	for i := 1; i < len(s); i++ {
+		if s[i].Epoch <= s[i-1].Epoch {
+			return fmt.Errorf(
+				"%w: fork at epoch %d follows fork at epoch %d",
+				ErrForkScheduleInvalid,
+				s[i].Epoch,
+				s[i-1].Epoch,
+			)
+		}
+	}
+
+	return nil
+}
+
+// GenesisVersion returns the fork version at genesis.
+func (s ForkSchedule) GenesisVersion() [ForkVersionLength]byte {
+	return s[0].Version
+}
+
+// VersionAt returns the fork version active at epoch.
+func (s ForkSchedule) VersionAt(epoch uint64) [ForkVersionLength]byte {
+	version := s[0].Version
+	for _, fork := range s[1:] {
+		if fork.Epoch > epoch {
+			break
+		}
+		version = fork.Version
+	}
+
+	return version
+}
+
+// ExitVersionAt returns the fork version voluntary exits
+// are signed with at epoch. Since Deneb, exits stay signed
+// with the Capella fork version (EIP-7044) so that they
+// remain valid across later forks.
+func (s ForkSchedule) ExitVersionAt(epoch uint64) [ForkVersionLength]byte {
+	version := s.VersionAt(epoch)
+	capella, deneb := s.fork(CapellaFork), s.fork(DenebFork)
+	if capella != nil && deneb != nil && deneb.Epoch <= epoch {
+		return capella.Version
+	}
+
+	return version
+}
+
+// fork returns the scheduled fork
+// named name, or nil if there is none.
+func (s ForkSchedule) fork(name string) *ScheduledFork {
+	for _, fork := range s {
+		if fork.Name == name {
+			return fork
+		}
+	}
+
+	return nil
+}
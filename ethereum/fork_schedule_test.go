@@ -0,0 +1,147 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForkSchedule_Validate(t *testing.T) {
+	tests := map[string]struct {
+		schedule ForkSchedule
+
+		expectedErr error
+	}{
+		"mainnet": {
+			schedule: MainnetForkSchedule,
+		},
+		"upgraded": {
+			schedule: ForkSchedule{
+				{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x00}},
+				{Epoch: 74240, Version: [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x00}},
+			},
+		},
+		"empty": {
+			schedule:    ForkSchedule{},
+			expectedErr: ErrForkScheduleInvalid,
+		},
+		"no genesis fork": {
+			schedule: ForkSchedule{
+				{Epoch: 10, Version: [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x00}},
+			},
+			expectedErr: ErrForkScheduleInvalid,
+		},
+		"out of order": {
+			schedule: ForkSchedule{
+				{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x00}},
+				{Epoch: 20, Version: [ForkVersionLength]byte{0x02, 0x00, 0x00, 0x00}},
+				{Epoch: 20, Version: [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x00}},
+			},
+			expectedErr: ErrForkScheduleInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.schedule.Validate()
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.expectedErr))
+			}
+		})
+	}
+}
+
+func TestForkSchedule_VersionAt(t *testing.T) {
+	schedule := ForkSchedule{
+		{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x01}},
+		{Epoch: 10, Version: [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x01}},
+		{Epoch: 20, Version: [ForkVersionLength]byte{0x02, 0x00, 0x00, 0x01}},
+	}
+
+	assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x01}, schedule.GenesisVersion())
+	assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x01}, schedule.VersionAt(0))
+	assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x01}, schedule.VersionAt(9))
+	assert.Equal(t, [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x01}, schedule.VersionAt(10))
+	assert.Equal(t, [ForkVersionLength]byte{0x01, 0x00, 0x00, 0x01}, schedule.VersionAt(19))
+	assert.Equal(t, [ForkVersionLength]byte{0x02, 0x00, 0x00, 0x01}, schedule.VersionAt(1000))
+}
+
+func TestForkSchedule_ExitVersionAt(t *testing.T) {
+	tests := map[string]struct {
+		epoch uint64
+
+		expected string
+	}{
+		"genesis":      {epoch: 0, expected: "00000000"},
+		"altair":       {epoch: 74240, expected: "01000000"},
+		"capella":      {epoch: 194048, expected: "03000000"},
+		"before deneb": {epoch: 269567, expected: "03000000"},
+		"deneb":        {epoch: 269568, expected: "03000000"},
+		"after deneb":  {epoch: 300000, expected: "03000000"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			version := MainnetForkSchedule.ExitVersionAt(test.epoch)
+			assert.Equal(t, test.expected, hex.EncodeToString(version[:]))
+		})
+	}
+
+	// Schedules without named forks sign
+	// with the version active at the epoch.
+	schedule := ForkSchedule{
+		{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x01}},
+		{Epoch: 10, Version: [ForkVersionLength]byte{0x04, 0x00, 0x00, 0x01}},
+	}
+	assert.Equal(t, [ForkVersionLength]byte{0x04, 0x00, 0x00, 0x01}, schedule.ExitVersionAt(10))
+}
+
+func TestForkSchedule_BuiltIn(t *testing.T) {
+	tests := map[string]struct {
+		schedule ForkSchedule
+		epoch    uint64
+
+		expected string
+	}{
+		"mainnet genesis":          {schedule: MainnetForkSchedule, epoch: 0, expected: "00000000"},
+		"mainnet before altair":    {schedule: MainnetForkSchedule, epoch: 74239, expected: "00000000"},
+		"mainnet altair":           {schedule: MainnetForkSchedule, epoch: 74240, expected: "01000000"},
+		"mainnet before bellatrix": {schedule: MainnetForkSchedule, epoch: 144895, expected: "01000000"},
+		"mainnet bellatrix":        {schedule: MainnetForkSchedule, epoch: 144896, expected: "02000000"},
+		"mainnet before capella":   {schedule: MainnetForkSchedule, epoch: 194047, expected: "02000000"},
+		"mainnet capella":          {schedule: MainnetForkSchedule, epoch: 194048, expected: "03000000"},
+		"mainnet before deneb":     {schedule: MainnetForkSchedule, epoch: 269567, expected: "03000000"},
+		"mainnet deneb":            {schedule: MainnetForkSchedule, epoch: 269568, expected: "04000000"},
+		"pyrmont genesis":          {schedule: TestnetForkSchedule, epoch: 0, expected: "00002009"},
+		"pyrmont before altair":    {schedule: TestnetForkSchedule, epoch: 61649, expected: "00002009"},
+		"pyrmont altair":           {schedule: TestnetForkSchedule, epoch: 61650, expected: "01002009"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, test.schedule.Validate())
+
+			version := test.schedule.VersionAt(test.epoch)
+			assert.Equal(t, test.expected, hex.EncodeToString(version[:]))
+		})
+	}
+}
+
+func TestGenesisForkSchedule(t *testing.T) {
+	schedule, err := GenesisForkSchedule("0x00002009")
+	assert.NoError(t, err)
+	assert.Equal(t, ForkSchedule{
+		{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x20, 0x09}},
+	}, schedule)
+
+	schedule, err = GenesisForkSchedule("")
+	assert.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	_, err = GenesisForkSchedule("0x000020")
+	assert.True(t, errors.Is(err, ErrForkScheduleInvalid))
+}
@@ -0,0 +1,174 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	ethv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeForkClient serves the fork of the head
+// state, or answers with err when set.
+type fakeForkClient struct {
+	ethv1.BeaconChainClient
+
+	version []byte
+	err     error
+}
+
+func (c *fakeForkClient) GetStateFork(
+	context.Context,
+	*ethv1.StateRequest,
+	...grpc.CallOption,
+) (*ethv1.StateForkResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &ethv1.StateForkResponse{Fork: &ethv1.Fork{CurrentVersion: c.version}}, nil
+}
+
+func TestClient_Fork(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("head fork", func(t *testing.T) {
+		devnet := *MainnetNetworkConfig
+		devnet.ForkSchedule = nil
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", &devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		fork, err := client.Fork(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), fork.Epoch)
+		assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}, fork.CurrentVersion)
+		assert.Equal(t, fork.CurrentVersion, fork.GenesisVersion)
+		assert.Equal(t, mainnetGenesisValidatorsRoot, fork.GenesisValidatorsRoot[:])
+	})
+
+	t.Run("scheduled fork", func(t *testing.T) {
+		devnet := *MainnetNetworkConfig
+		devnet.ForkSchedule = ForkSchedule{
+			{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}},
+			{Epoch: 0x10, Version: [ForkVersionLength]byte{0x01, 0x00, 0x10, 0x20}},
+		}
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", &devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		fork, err := client.Fork(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}, fork.CurrentVersion)
+		assert.Equal(t, fork.CurrentVersion, fork.GenesisVersion)
+	})
+
+	t.Run("reported fork", func(t *testing.T) {
+		devnet := *MainnetNetworkConfig
+		devnet.ForkSchedule = nil
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", &devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+		client.poolClient = &fakeForkClient{version: []byte{0x01, 0x00, 0x10, 0x20}}
+
+		fork, err := client.Fork(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, [ForkVersionLength]byte{0x01, 0x00, 0x10, 0x20}, fork.CurrentVersion)
+		assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}, fork.GenesisVersion)
+	})
+
+	t.Run("reported fork of the schedule", func(t *testing.T) {
+		devnet := *MainnetNetworkConfig
+		devnet.ForkSchedule = ForkSchedule{
+			{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}},
+			{Epoch: 0x10, Version: [ForkVersionLength]byte{0x01, 0x00, 0x10, 0x20}},
+		}
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", &devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+		client.poolClient = &fakeForkClient{version: []byte{0x00, 0x00, 0x10, 0x20}}
+
+		fork, err := client.Fork(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}, fork.CurrentVersion)
+	})
+
+	t.Run("fork version mismatch", func(t *testing.T) {
+		devnet := *MainnetNetworkConfig
+		devnet.ForkSchedule = ForkSchedule{
+			{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}},
+			{Epoch: 0x10, Version: [ForkVersionLength]byte{0x01, 0x00, 0x10, 0x20}},
+		}
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", &devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+		client.poolClient = &fakeForkClient{version: []byte{0x01, 0x00, 0x10, 0x20}}
+
+		fork, err := client.Fork(ctx)
+		assert.Nil(t, fork)
+		assert.True(t, errors.Is(err, ErrForkVersionMismatch))
+	})
+
+	t.Run("genesis fork version mismatch", func(t *testing.T) {
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", MainnetNetworkConfig)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		fork, err := client.Fork(ctx)
+		assert.Nil(t, fork)
+		assert.True(t, errors.Is(err, ErrGenesisForkVersionMismatch))
+	})
+
+	t.Run("genesis validators root mismatch", func(t *testing.T) {
+		devnet := *MainnetNetworkConfig
+		devnet.GenesisValidatorsRoot = strings.Repeat("ab", rootLength)
+		client, err := NewClient(ctx, startFakeBeacon(t, &fakeBeaconChainServer{}), "", &devnet)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		fork, err := client.Fork(ctx)
+		assert.Nil(t, fork)
+		assert.True(t, errors.Is(err, ErrGenesisValidatorsRootMismatch))
+	})
+}
+
+func TestParseConfigBytes(t *testing.T) {
+	tests := map[string]struct {
+		value string
+
+		expected    []byte
+		expectedErr bool
+	}{
+		"fork version": {
+			value:    "[0 0 16 32]",
+			expected: []byte{0x00, 0x00, 0x10, 0x20},
+		},
+		"empty": {
+			value:    "[]",
+			expected: []byte{},
+		},
+		"not a list": {
+			value:       "0x00001020",
+			expectedErr: true,
+		},
+		"out of range": {
+			value:       "[0 0 16 256]",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := parseConfigBytes(test.value)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// attestationPoolPageSize is the number of
+	// attestations requested per page.
+	attestationPoolPageSize = 250
+)
+
+// Mempool returns the identifiers of the operations
+// pending in the pools of the beacon node.
+func (ec *Client) Mempool(ctx context.Context) ([]*RosettaTypes.TransactionIdentifier, error) {
+	ctx, span := tracer.Start(ctx, "Client.Mempool")
+	defer span.End()
+
+	transactions, err := ec.pendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]*RosettaTypes.TransactionIdentifier, len(transactions))
+	for i, transaction := range transactions {
+		identifiers[i] = transaction.TransactionIdentifier
+	}
+
+	return identifiers, nil
+}
+
+// MempoolTransaction returns the pending operation identified
+// by transaction. Operations leave the pools once included in
+// a block or evicted, after which ErrTransactionNotFound is
+// returned.
+func (ec *Client) MempoolTransaction(
+	ctx context.Context,
+	transaction *RosettaTypes.TransactionIdentifier,
+) (*RosettaTypes.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "Client.MempoolTransaction")
+	defer span.End()
+
+	transactions, err := ec.pendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := strings.ToLower(trimHash(transaction.Hash))
+	for _, pending := range transactions {
+		if pending.TransactionIdentifier.Hash == hash {
+			return pending, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s is not pending", ErrTransactionNotFound, transaction.Hash)
+}
+
+// pendingTransactions returns the transactions of the operations
+// pending in the pools of the beacon node. Voluntary exits are
+// encoded as in /block, which lists no other operation.
+func (ec *Client) pendingTransactions(ctx context.Context) ([]*RosettaTypes.Transaction, error) {
+	pool, err := ec.pendingOperations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.blockTransactions(ctx, pool)
+}
+
+// pendingOperations returns the operations pending in the
+// pools of the beacon node as the body of a block.
+//
+// Exits and slashings are only pooled through the eth/v1
+// API, which older prysm releases do not serve; their
+// pools are then reported as empty.
+func (ec *Client) pendingOperations(ctx context.Context) (*pb.BeaconBlockBody, error) {
+	pool := &pb.BeaconBlockBody{}
+
+	attestations, err := ec.attestationPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool.Attestations = attestations
+
+	exits, err := ec.poolClient.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	if err := poolErr(err); err != nil {
+		return nil, fmt.Errorf("%w: could not list pending voluntary exits", err)
+	}
+	for _, exit := range exits.GetData() {
+		converted := &pb.SignedVoluntaryExit{}
+		if err := convertV1(exit, converted); err != nil {
+			return nil, err
+		}
+		pool.VoluntaryExits = append(pool.VoluntaryExits, converted)
+	}
+
+	proposerSlashings, err := ec.poolClient.ListPoolProposerSlashings(ctx, &types.Empty{})
+	if err := poolErr(err); err != nil {
+		return nil, fmt.Errorf("%w: could not list pending proposer slashings", err)
+	}
+	for _, slashing := range proposerSlashings.GetData() {
+		converted := &pb.ProposerSlashing{}
+		if err := convertV1(slashing, converted); err != nil {
+			return nil, err
+		}
+		pool.ProposerSlashings = append(pool.ProposerSlashings, converted)
+	}
+
+	attesterSlashings, err := ec.poolClient.ListPoolAttesterSlashings(ctx, &types.Empty{})
+	if err := poolErr(err); err != nil {
+		return nil, fmt.Errorf("%w: could not list pending attester slashings", err)
+	}
+	for _, slashing := range attesterSlashings.GetData() {
+		converted := &pb.AttesterSlashing{}
+		if err := convertV1(slashing, converted); err != nil {
+			return nil, err
+		}
+		pool.AttesterSlashings = append(pool.AttesterSlashings, converted)
+	}
+
+	return pool, nil
+}
+
+// attestationPool returns all pages of
+// the attestation pool of the beacon node.
+func (ec *Client) attestationPool(ctx context.Context) ([]*pb.Attestation, error) {
+	attestations := []*pb.Attestation{}
+	pageToken := ""
+	for {
+		res, err := ec.beaconChainClient.AttestationPool(ctx, &pb.AttestationPoolRequest{
+			PageSize:  attestationPoolPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not list pending attestations", err)
+		}
+
+		attestations = append(attestations, res.Attestations...)
+		if len(res.NextPageToken) == 0 || len(res.Attestations) == 0 {
+			return attestations, nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
+
+// poolErr returns err unless the beacon
+// node does not serve the pool RPC.
+func poolErr(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+
+	return err
+}
+
+// convertV1 converts an eth/v1 operation to its v1alpha1
+// equivalent, which shares its wire encoding.
+func convertV1(operation proto.Message, converted proto.Message) error {
+	b, err := proto.Marshal(operation)
+	if err != nil {
+		return fmt.Errorf("%w: could not marshal %T", err, operation)
+	}
+
+	if err := proto.Unmarshal(b, converted); err != nil {
+		return fmt.Errorf("%w: could not unmarshal %T", err, converted)
+	}
+
+	return nil
+}
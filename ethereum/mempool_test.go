@@ -0,0 +1,285 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	types "github.com/gogo/protobuf/types"
+	ethv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAttestationPoolClient serves attestations
+// from the attestation pool one per page.
+type fakeAttestationPoolClient struct {
+	pb.BeaconChainClient
+
+	attestations []*pb.Attestation
+	err          error
+}
+
+func (c *fakeAttestationPoolClient) AttestationPool(
+	ctx context.Context,
+	req *pb.AttestationPoolRequest,
+	opts ...grpc.CallOption,
+) (*pb.AttestationPoolResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	page := 0
+	if len(req.PageToken) > 0 {
+		page, _ = strconv.Atoi(req.PageToken)
+	}
+	if page >= len(c.attestations) {
+		return &pb.AttestationPoolResponse{}, nil
+	}
+
+	nextPageToken := strconv.Itoa(page + 1)
+	if page+1 == len(c.attestations) {
+		nextPageToken = ""
+	}
+
+	return &pb.AttestationPoolResponse{
+		Attestations:  c.attestations[page : page+1],
+		NextPageToken: nextPageToken,
+		TotalSize:     int32(len(c.attestations)),
+	}, nil
+}
+
+// fakePoolClient serves the eth/v1 operation pools,
+// or answers with err when set.
+type fakePoolClient struct {
+	ethv1.BeaconChainClient
+
+	exits             []*ethv1.SignedVoluntaryExit
+	proposerSlashings []*ethv1.ProposerSlashing
+	attesterSlashings []*ethv1.AttesterSlashing
+	err               error
+}
+
+func (c *fakePoolClient) ListPoolVoluntaryExits(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*ethv1.VoluntaryExitsPoolResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &ethv1.VoluntaryExitsPoolResponse{Data: c.exits}, nil
+}
+
+func (c *fakePoolClient) ListPoolProposerSlashings(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*ethv1.ProposerSlashingPoolResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &ethv1.ProposerSlashingPoolResponse{Data: c.proposerSlashings}, nil
+}
+
+func (c *fakePoolClient) ListPoolAttesterSlashings(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*ethv1.AttesterSlashingsPoolResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &ethv1.AttesterSlashingsPoolResponse{Data: c.attesterSlashings}, nil
+}
+
+// testPoolClient returns a fakePoolClient holding a
+// pending exit of validator 7 and a proposer
+// slashing of validator 4.
+func testPoolClient() *fakePoolClient {
+	return &fakePoolClient{
+		exits: []*ethv1.SignedVoluntaryExit{
+			{
+				Exit:      &ethv1.VoluntaryExit{Epoch: 3, ValidatorIndex: 7},
+				Signature: make([]byte, SignatureLength),
+			},
+		},
+		proposerSlashings: []*ethv1.ProposerSlashing{
+			{
+				Header_1: testV1Header(0x01),
+				Header_2: testV1Header(0x02),
+			},
+		},
+	}
+}
+
+// testV1Header returns the eth/v1 equivalent of
+// testHeader(100, 4, bodyRoot).
+func testV1Header(bodyRoot byte) *ethv1.SignedBeaconBlockHeader {
+	header := testHeader(100, 4, bodyRoot)
+	return &ethv1.SignedBeaconBlockHeader{
+		Header: &ethv1.BeaconBlockHeader{
+			Slot:          header.Header.Slot,
+			ProposerIndex: header.Header.ProposerIndex,
+			ParentRoot:    header.Header.ParentRoot,
+			StateRoot:     header.Header.StateRoot,
+			BodyRoot:      header.Header.BodyRoot,
+		},
+		Signature: header.Signature,
+	}
+}
+
+func TestClient_Mempool(t *testing.T) {
+	ctx := context.Background()
+	attestations := []*pb.Attestation{testAttestation(99, []byte{0x0d}), testAttestation(100, []byte{0x0e})}
+	registry := NewValidatorRegistry(&fakeRegistryClient{size: 8}, MainnetNetworkConfig, DefaultValidatorRegistrySize)
+
+	// The identifiers of pending operations are those
+	// they are given once included in a block.
+	proposerSlashing := &pb.ProposerSlashing{
+		Header_1: testHeader(100, 4, 0x01),
+		Header_2: testHeader(100, 4, 0x02),
+	}
+	proposerSlashingRoot, err := proposerSlashing.HashTreeRoot()
+	assert.NoError(t, err)
+	attestationRoots := make([][rootLength]byte, len(attestations))
+	for i, attestation := range attestations {
+		attestationRoots[i], err = attestation.HashTreeRoot()
+		assert.NoError(t, err)
+	}
+	exitRoot := VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: 3, ValidatorIndex: 7})
+
+	tests := map[string]struct {
+		attestationPool *fakeAttestationPoolClient
+		pool            *fakePoolClient
+
+		expected    []*RosettaTypes.TransactionIdentifier
+		expectedErr bool
+	}{
+		"all pools": {
+			attestationPool: &fakeAttestationPoolClient{attestations: attestations},
+			pool:            testPoolClient(),
+			expected: []*RosettaTypes.TransactionIdentifier{
+				{Hash: operationHash(proposerSlashingRoot)},
+				{Hash: operationHash(attestationRoots[0])},
+				{Hash: operationHash(attestationRoots[1])},
+				{Hash: operationHash(exitRoot)},
+			},
+		},
+		"empty pools": {
+			attestationPool: &fakeAttestationPoolClient{},
+			pool:            &fakePoolClient{},
+			expected:        []*RosettaTypes.TransactionIdentifier{},
+		},
+		"eth/v1 pools not served": {
+			attestationPool: &fakeAttestationPoolClient{attestations: attestations[:1]},
+			pool:            &fakePoolClient{err: status.Error(codes.Unimplemented, "unknown service")},
+			expected: []*RosettaTypes.TransactionIdentifier{
+				{Hash: operationHash(attestationRoots[0])},
+			},
+		},
+		"attestation pool error": {
+			attestationPool: &fakeAttestationPoolClient{err: status.Error(codes.Internal, "pool unavailable")},
+			pool:            testPoolClient(),
+			expectedErr:     true,
+		},
+		"eth/v1 pool error": {
+			attestationPool: &fakeAttestationPoolClient{},
+			pool:            &fakePoolClient{err: errors.New("connection reset")},
+			expectedErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &Client{
+				network:           MainnetNetworkConfig,
+				beaconChainClient: test.attestationPool,
+				poolClient:        test.pool,
+				validators:        registry,
+			}
+
+			identifiers, err := client.Mempool(ctx)
+			if test.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, identifiers)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, identifiers)
+			}
+		})
+	}
+}
+
+func TestClient_MempoolTransaction(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{
+		network:           MainnetNetworkConfig,
+		beaconChainClient: &fakeAttestationPoolClient{attestations: []*pb.Attestation{testAttestation(99, []byte{0x0d})}},
+		poolClient:        testPoolClient(),
+		validators:        NewValidatorRegistry(&fakeRegistryClient{size: 8}, MainnetNetworkConfig, DefaultValidatorRegistrySize),
+	}
+
+	exitRoot := VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: 3, ValidatorIndex: 7})
+	expected := &RosettaTypes.Transaction{
+		TransactionIdentifier: &RosettaTypes.TransactionIdentifier{Hash: operationHash(exitRoot)},
+		Operations: []*RosettaTypes.Operation{
+			{
+				OperationIdentifier: &RosettaTypes.OperationIdentifier{Index: 0},
+				Type:                VoluntaryExitOpType,
+				Status:              RosettaTypes.String(SuccessStatus),
+				Account:             &RosettaTypes.AccountIdentifier{Address: ValidatorAddress(testPubkey(7))},
+				Metadata:            map[string]interface{}{"validator_index": "7", "epoch": "3"},
+			},
+		},
+	}
+
+	t.Run("pending", func(t *testing.T) {
+		transaction, err := client.MempoolTransaction(ctx, &RosettaTypes.TransactionIdentifier{
+			Hash: operationHash(exitRoot),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, transaction)
+	})
+
+	t.Run("0x-prefixed upper-case hash", func(t *testing.T) {
+		transaction, err := client.MempoolTransaction(ctx, &RosettaTypes.TransactionIdentifier{
+			Hash: "0x" + strings.ToUpper(operationHash(exitRoot)),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, transaction)
+	})
+
+	t.Run("not pending", func(t *testing.T) {
+		root := VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: 3, ValidatorIndex: 6})
+		transaction, err := client.MempoolTransaction(ctx, &RosettaTypes.TransactionIdentifier{
+			Hash: operationHash(root),
+		})
+		assert.Nil(t, transaction)
+		assert.True(t, errors.Is(err, ErrTransactionNotFound))
+	})
+
+	t.Run("pool error", func(t *testing.T) {
+		failing := &Client{
+			network:           MainnetNetworkConfig,
+			beaconChainClient: &fakeAttestationPoolClient{},
+			poolClient:        &fakePoolClient{err: errors.New("connection reset")},
+			validators:        client.validators,
+		}
+		transaction, err := failing.MempoolTransaction(ctx, &RosettaTypes.TransactionIdentifier{
+			Hash: operationHash(exitRoot),
+		})
+		assert.Nil(t, transaction)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrTransactionNotFound))
+	})
+}
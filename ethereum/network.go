@@ -0,0 +1,243 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	// MainnetPreset is the name of the mainnet
+	// chain config preset.
+	MainnetPreset = "mainnet"
+
+	// MinimalPreset is the name of the minimal chain config
+	// preset commonly used by local devnets.
+	MinimalPreset = "minimal"
+
+	// rootLength is the length in bytes
+	// of a 32-byte SSZ root.
+	rootLength = 32
+)
+
+var (
+	// ErrNetworkNameMissing is returned when a
+	// network has no name.
+	ErrNetworkNameMissing = errors.New("network name must be populated")
+
+	// ErrPresetInvalid is returned when a network
+	// references an unknown preset.
+	ErrPresetInvalid = errors.New("chain config preset invalid")
+
+	// ErrGenesisValidatorsRootInvalid is returned when the
+	// genesis validators root is not a 32-byte hex string.
+	ErrGenesisValidatorsRootInvalid = errors.New("genesis validators root invalid")
+
+	// ErrGenesisTimeInvalid is returned when the
+	// genesis time is not populated.
+	ErrGenesisTimeInvalid = errors.New("genesis time invalid")
+
+	// ErrGenesisBlockHashInvalid is returned when the
+	// genesis block hash is not a 32-byte hex string.
+	ErrGenesisBlockHashInvalid = errors.New("genesis block hash invalid")
+
+	// ErrPrysmFlagsInvalid is returned when the prysm
+	// flags of a network do not start with a flag.
+	ErrPrysmFlagsInvalid = errors.New("network prysm flags invalid")
+)
+
+// Preset is a set of consensus constants
+// shared by several networks.
+type Preset struct {
+	Name           string
+	SecondsPerSlot uint64
+	SlotsPerEpoch  uint64
+
+	// Deposits are processed once the eth1 block including
+	// them is Eth1FollowDistance blocks deep and voted on
+	// during an eth1 voting period, at most MaxDeposits
+	// per block.
+	SecondsPerEth1Block       uint64
+	Eth1FollowDistance        uint64
+	EpochsPerEth1VotingPeriod uint64
+	MaxDeposits               uint64
+
+	// PrysmFlags select the preset in prysm.
+	PrysmFlags []string
+}
+
+// NetworkConfig describes an Ethereum 2.0
+// network this implementation can serve.
+type NetworkConfig struct {
+	// Name is the Network of the
+	// *types.NetworkIdentifier.
+	Name   string
+	Preset *Preset
+
+	// GenesisValidatorsRoot is the hex-encoded genesis
+	// validators root. It may be empty, in which case
+	// the beacon node is queried.
+	GenesisValidatorsRoot string
+
+	// GenesisTime is the unix time of slot 0.
+	GenesisTime int64
+
+	// GenesisBlockIdentifier is the identifier of the
+	// slot 0 block, or nil if it is not known in advance.
+	GenesisBlockIdentifier *types.BlockIdentifier
+
+	// ForkSchedule lists the fork versions of the network.
+	// It may be nil, in which case the beacon node is queried.
+	ForkSchedule ForkSchedule
+
+	// PrysmFlags select the network in prysm.
+	PrysmFlags []string
+}
+
+var (
+	// Presets are all supported chain config presets.
+	Presets = map[string]*Preset{
+		MainnetPreset: {
+			Name:           MainnetPreset,
+			SecondsPerSlot: 12,
+			SlotsPerEpoch:  32,
+
+			SecondsPerEth1Block:       14,
+			Eth1FollowDistance:        2048,
+			EpochsPerEth1VotingPeriod: 64,
+			MaxDeposits:               16,
+		},
+		MinimalPreset: {
+			Name:           MinimalPreset,
+			SecondsPerSlot: 6,
+			SlotsPerEpoch:  8,
+
+			SecondsPerEth1Block:       14,
+			Eth1FollowDistance:        16,
+			EpochsPerEth1VotingPeriod: 4,
+			MaxDeposits:               16,
+
+			PrysmFlags: []string{"--minimal-config"},
+		},
+	}
+
+	// MainnetNetworkConfig is the *NetworkConfig
+	// of the Ethereum 2.0 Mainnet.
+	MainnetNetworkConfig = &NetworkConfig{
+		Name:                   MainnetNetwork,
+		Preset:                 Presets[MainnetPreset],
+		GenesisValidatorsRoot:  "4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		GenesisTime:            1606824023,
+		GenesisBlockIdentifier: MainnetGenesisBlockIdentifier,
+		ForkSchedule:           MainnetForkSchedule,
+	}
+
+	// TestnetNetworkConfig is the *NetworkConfig
+	// of the Pyrmont testnet.
+	TestnetNetworkConfig = &NetworkConfig{
+		Name:                   TestnetNetwork,
+		Preset:                 Presets[MainnetPreset],
+		GenesisValidatorsRoot:  "4a26c58b08add8089b75caa540848881a8d4f0af0be83417a85c0f45f4f8a8ac",
+		GenesisTime:            1605700807,
+		GenesisBlockIdentifier: TestnetGenesisBlockIdentifier,
+		ForkSchedule:           TestnetForkSchedule,
+		PrysmFlags:             []string{"--pyrmont"},
+	}
+)
+
+// Validate returns an error if the
+// NetworkConfig is not complete.
+func (n *NetworkConfig) Validate() error {
+	if len(n.Name) == 0 {
+		return ErrNetworkNameMissing
+	}
+
+	if n.Preset == nil {
+		return ErrPresetInvalid
+	}
+
+	if len(n.GenesisValidatorsRoot) > 0 && !isRoot(n.GenesisValidatorsRoot) {
+		return fmt.Errorf("%w: %s", ErrGenesisValidatorsRootInvalid, n.GenesisValidatorsRoot)
+	}
+
+	if n.GenesisTime <= 0 {
+		return fmt.Errorf("%w: %d", ErrGenesisTimeInvalid, n.GenesisTime)
+	}
+
+	if n.GenesisBlockIdentifier != nil {
+		if n.GenesisBlockIdentifier.Index != 0 || !isRoot(n.GenesisBlockIdentifier.Hash) {
+			return fmt.Errorf("%w: %s", ErrGenesisBlockHashInvalid, n.GenesisBlockIdentifier.Hash)
+		}
+	}
+
+	if n.ForkSchedule != nil {
+		if err := n.ForkSchedule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(n.PrysmFlags) > 0 && !isFlag(n.PrysmFlags[0]) {
+		return fmt.Errorf("%w: %s", ErrPrysmFlagsInvalid, n.PrysmFlags[0])
+	}
+
+	return nil
+}
+
+// PrysmArguments returns the prysm flags selecting
+// both the preset and the network.
+func (n *NetworkConfig) PrysmArguments() []string {
+	args := append([]string{}, n.Preset.PrysmFlags...)
+	return append(args, n.PrysmFlags...)
+}
+
+// Epoch returns the epoch at t, or 0 before genesis.
+func (n *NetworkConfig) Epoch(t time.Time) uint64 {
+	elapsed := t.Unix() - n.GenesisTime
+	if elapsed < 0 {
+		return 0
+	}
+
+	return uint64(elapsed) / n.Preset.SecondsPerSlot / n.Preset.SlotsPerEpoch
+}
+
+// Domain returns the signature domain of domainType at epoch,
+// computed offline from the fork schedule and the genesis
+// validators root of the network.
+func (n *NetworkConfig) Domain(domainType DomainType, epoch uint64) ([rootLength]byte, error) {
+	if len(n.ForkSchedule) == 0 {
+		return [rootLength]byte{}, fmt.Errorf("%w: %s has no fork schedule", ErrForkScheduleInvalid, n.Name)
+	}
+
+	// Deposits are valid across forks and
+	// may be signed before genesis.
+	if domainType == DomainDeposit {
+		return DepositDomain(n.ForkSchedule.GenesisVersion()), nil
+	}
+
+	b, err := hex.DecodeString(trimHash(n.GenesisValidatorsRoot))
+	if err != nil || len(b) != rootLength {
+		return [rootLength]byte{}, fmt.Errorf(
+			"%w: %s has no genesis validators root",
+			ErrGenesisValidatorsRootInvalid,
+			n.Name,
+		)
+	}
+
+	var genesisValidatorsRoot [rootLength]byte
+	copy(genesisValidatorsRoot[:], b)
+	if domainType == DomainVoluntaryExit {
+		return ComputeDomain(domainType, n.ForkSchedule.ExitVersionAt(epoch), genesisValidatorsRoot), nil
+	}
+
+	return ComputeDomain(domainType, n.ForkSchedule.VersionAt(epoch), genesisValidatorsRoot), nil
+}
+
+// isRoot returns true if root is a
+// hex-encoded 32-byte root.
+func isRoot(root string) bool {
+	b, err := hex.DecodeString(trimHash(root))
+	return err == nil && len(b) == rootLength
+}
@@ -0,0 +1,175 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkConfig_Validate(t *testing.T) {
+	valid := func() *NetworkConfig {
+		return &NetworkConfig{
+			Name:                  "devnet",
+			Preset:                Presets[MinimalPreset],
+			GenesisValidatorsRoot: "0x" + MainnetNetworkConfig.GenesisValidatorsRoot,
+			GenesisTime:           1606824023,
+			PrysmFlags:            []string{"--chain-config-file", "/app/devnet/config.yaml"},
+		}
+	}
+
+	tests := map[string]struct {
+		modify func(*NetworkConfig)
+
+		expectedError error
+	}{
+		"valid": {
+			modify: func(*NetworkConfig) {},
+		},
+		"valid genesis block": {
+			modify: func(n *NetworkConfig) {
+				n.GenesisBlockIdentifier = MainnetGenesisBlockIdentifier
+			},
+		},
+		"missing name": {
+			modify:        func(n *NetworkConfig) { n.Name = "" },
+			expectedError: ErrNetworkNameMissing,
+		},
+		"missing preset": {
+			modify:        func(n *NetworkConfig) { n.Preset = nil },
+			expectedError: ErrPresetInvalid,
+		},
+		"short genesis validators root": {
+			modify:        func(n *NetworkConfig) { n.GenesisValidatorsRoot = "4b363db9" },
+			expectedError: ErrGenesisValidatorsRootInvalid,
+		},
+		"missing genesis time": {
+			modify:        func(n *NetworkConfig) { n.GenesisTime = 0 },
+			expectedError: ErrGenesisTimeInvalid,
+		},
+		"genesis block not at slot 0": {
+			modify: func(n *NetworkConfig) {
+				n.GenesisBlockIdentifier = &types.BlockIdentifier{
+					Index: 1,
+					Hash:  MainnetGenesisBlockIdentifier.Hash,
+				}
+			},
+			expectedError: ErrGenesisBlockHashInvalid,
+		},
+		"fork schedule": {
+			modify: func(n *NetworkConfig) {
+				n.ForkSchedule = ForkSchedule{{Epoch: 0, Version: [ForkVersionLength]byte{0x00, 0x00, 0x00, 0x01}}}
+			},
+		},
+		"fork schedule without genesis fork": {
+			modify:        func(n *NetworkConfig) { n.ForkSchedule = ForkSchedule{} },
+			expectedError: ErrForkScheduleInvalid,
+		},
+		"prysm flags without flag": {
+			modify:        func(n *NetworkConfig) { n.PrysmFlags = []string{"pyrmont"} },
+			expectedError: ErrPrysmFlagsInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := valid()
+			test.modify(config)
+
+			err := config.Validate()
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.expectedError))
+			}
+		})
+	}
+
+	assert.NoError(t, MainnetNetworkConfig.Validate())
+	assert.NoError(t, TestnetNetworkConfig.Validate())
+}
+
+func TestNetworkConfig_PrysmArguments(t *testing.T) {
+	assert.Empty(t, MainnetNetworkConfig.PrysmArguments())
+	assert.Equal(t, []string{"--pyrmont"}, TestnetNetworkConfig.PrysmArguments())
+
+	devnet := &NetworkConfig{
+		Preset:     Presets[MinimalPreset],
+		PrysmFlags: []string{"--chain-config-file=/app/devnet/config.yaml"},
+	}
+	assert.Equal(t, []string{
+		"--minimal-config",
+		"--chain-config-file=/app/devnet/config.yaml",
+	}, devnet.PrysmArguments())
+
+	// The preset flags must not be modified.
+	assert.Equal(t, []string{"--minimal-config"}, Presets[MinimalPreset].PrysmFlags)
+}
+
+func TestNetworkConfig_Epoch(t *testing.T) {
+	genesis := time.Unix(MainnetNetworkConfig.GenesisTime, 0)
+
+	assert.Equal(t, uint64(0), MainnetNetworkConfig.Epoch(genesis.Add(-time.Hour)))
+	assert.Equal(t, uint64(0), MainnetNetworkConfig.Epoch(genesis.Add(383*time.Second)))
+	assert.Equal(t, uint64(1), MainnetNetworkConfig.Epoch(genesis.Add(384*time.Second)))
+
+	devnet := &NetworkConfig{Preset: Presets[MinimalPreset], GenesisTime: MainnetNetworkConfig.GenesisTime}
+	assert.Equal(t, uint64(2), devnet.Epoch(genesis.Add(96*time.Second)))
+}
+
+func TestNetworkConfig_Domain(t *testing.T) {
+	domain, err := MainnetNetworkConfig.Domain(DomainVoluntaryExit, 100)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"04000000b5303f2ad2010d699a76c8e62350947421a3e4a979779642cfdb0f66",
+		hex.EncodeToString(domain[:]),
+	)
+
+	domain, err = MainnetNetworkConfig.Domain(DomainDeposit, 100)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		hex.EncodeToString(domain[:]),
+	)
+
+	// Pyrmont signs with its genesis fork version 0x00002009.
+	domain, err = TestnetNetworkConfig.Domain(DomainDeposit, 0)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"03000000d54cf597931f07d7389844c66efe7dc7b947738f8b9268324936d344",
+		hex.EncodeToString(domain[:]),
+	)
+
+	domain, err = TestnetNetworkConfig.Domain(DomainVoluntaryExit, 0)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"040000004b70599db32d47f2756e6c92009e8d507c8a00ab94632ee4179a0647",
+		hex.EncodeToString(domain[:]),
+	)
+
+	// Exits after Deneb are signed with the Capella fork version.
+	capella, err := MainnetNetworkConfig.Domain(DomainVoluntaryExit, 194048)
+	assert.NoError(t, err)
+	deneb, err := MainnetNetworkConfig.Domain(DomainVoluntaryExit, 300000)
+	assert.NoError(t, err)
+	assert.Equal(t, capella, deneb)
+
+	var root [rootLength]byte
+	copy(root[:], mainnetGenesisValidatorsRoot)
+	assert.NotEqual(t, ComputeDomain(DomainVoluntaryExit, [ForkVersionLength]byte{0x04}, root), deneb)
+
+	withoutRoot := &NetworkConfig{Name: "devnet", ForkSchedule: MainnetForkSchedule}
+	_, err = withoutRoot.Domain(DomainVoluntaryExit, 0)
+	assert.True(t, errors.Is(err, ErrGenesisValidatorsRootInvalid))
+
+	devnet := &NetworkConfig{Name: "devnet", GenesisValidatorsRoot: MainnetNetworkConfig.GenesisValidatorsRoot}
+	_, err = devnet.Domain(DomainVoluntaryExit, 0)
+	assert.True(t, errors.Is(err, ErrForkScheduleInvalid))
+}
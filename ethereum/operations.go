@@ -0,0 +1,208 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// Beacon operations are transactions identified by the
+// hex-encoded hash tree root of the operation, so that
+// pending operations keep their identifier once included.
+
+// operationHash returns the transaction
+// hash of an operation with root.
+func operationHash(root [rootLength]byte) string {
+	return hex.EncodeToString(root[:])
+}
+
+// blockTransactions returns the transactions of the
+// operations in body, in the order they are processed.
+func (ec *Client) blockTransactions(
+	ctx context.Context,
+	body *pb.BeaconBlockBody,
+) ([]*RosettaTypes.Transaction, error) {
+	proposerSlashings, err := ec.proposerSlashingTransactions(ctx, body.GetProposerSlashings())
+	if err != nil {
+		return nil, err
+	}
+
+	attesterSlashings, err := ec.attesterSlashingTransactions(ctx, body.GetAttesterSlashings())
+	if err != nil {
+		return nil, err
+	}
+
+	attestations, err := attestationTransactions(body.GetAttestations())
+	if err != nil {
+		return nil, err
+	}
+
+	exits, err := ec.voluntaryExitTransactions(ctx, body.GetVoluntaryExits())
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := append(proposerSlashings, attesterSlashings...)
+	transactions = append(transactions, attestations...)
+	return append(transactions, exits...), nil
+}
+
+// proposerSlashingTransactions returns a transaction
+// slashing the proposer of each slashing.
+func (ec *Client) proposerSlashingTransactions(
+	ctx context.Context,
+	slashings []*pb.ProposerSlashing,
+) ([]*RosettaTypes.Transaction, error) {
+	transactions := make([]*RosettaTypes.Transaction, len(slashings))
+	for i, slashing := range slashings {
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid proposer slashing", err)
+		}
+
+		header := slashing.GetHeader_1().GetHeader()
+		op, err := ec.slashingOperation(ctx, 0, ProposerSlashingOpType, header.GetProposerIndex())
+		if err != nil {
+			return nil, err
+		}
+		op.Metadata["slot"] = strconv.FormatUint(header.GetSlot(), 10)
+
+		transactions[i] = &RosettaTypes.Transaction{
+			TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
+				Hash: operationHash(root),
+			},
+			Operations: []*RosettaTypes.Operation{op},
+		}
+	}
+
+	return transactions, nil
+}
+
+// attesterSlashingTransactions returns a transaction for each
+// slashing with an operation per validator it slashes: those
+// attesting to both of its conflicting attestations.
+func (ec *Client) attesterSlashingTransactions(
+	ctx context.Context,
+	slashings []*pb.AttesterSlashing,
+) ([]*RosettaTypes.Transaction, error) {
+	transactions := make([]*RosettaTypes.Transaction, len(slashings))
+	for i, slashing := range slashings {
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid attester slashing", err)
+		}
+
+		slashed := intersectIndices(
+			slashing.GetAttestation_1().GetAttestingIndices(),
+			slashing.GetAttestation_2().GetAttestingIndices(),
+		)
+		ops := make([]*RosettaTypes.Operation, len(slashed))
+		for j, index := range slashed {
+			ops[j], err = ec.slashingOperation(ctx, int64(j), AttesterSlashingOpType, index)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		transactions[i] = &RosettaTypes.Transaction{
+			TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
+				Hash: operationHash(root),
+			},
+			Operations: ops,
+		}
+	}
+
+	return transactions, nil
+}
+
+// slashingOperation returns the operation
+// slashing the validator with index.
+func (ec *Client) slashingOperation(
+	ctx context.Context,
+	opIndex int64,
+	opType string,
+	index uint64,
+) (*RosettaTypes.Operation, error) {
+	pubkey, err := ec.validators.Pubkey(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RosettaTypes.Operation{
+		OperationIdentifier: &RosettaTypes.OperationIdentifier{
+			Index: opIndex,
+		},
+		Type:   opType,
+		Status: RosettaTypes.String(SuccessStatus),
+		Account: &RosettaTypes.AccountIdentifier{
+			Address: ValidatorAddress(pubkey),
+		},
+		Metadata: map[string]interface{}{
+			"validator_index": strconv.FormatUint(index, 10),
+		},
+	}, nil
+}
+
+// attestationTransactions returns a transaction for each
+// attestation. Attesters are only known through committee
+// assignments, so attestations carry no account.
+func attestationTransactions(attestations []*pb.Attestation) ([]*RosettaTypes.Transaction, error) {
+	transactions := make([]*RosettaTypes.Transaction, len(attestations))
+	for i, attestation := range attestations {
+		root, err := attestation.HashTreeRoot()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid attestation", err)
+		}
+
+		data := attestation.GetData()
+		transactions[i] = &RosettaTypes.Transaction{
+			TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
+				Hash: operationHash(root),
+			},
+			Operations: []*RosettaTypes.Operation{
+				{
+					OperationIdentifier: &RosettaTypes.OperationIdentifier{
+						Index: 0,
+					},
+					Type:   AttestationOpType,
+					Status: RosettaTypes.String(SuccessStatus),
+					Metadata: map[string]interface{}{
+						"slot":              strconv.FormatUint(data.GetSlot(), 10),
+						"committee_index":   strconv.FormatUint(data.GetCommitteeIndex(), 10),
+						"beacon_block_root": hex.EncodeToString(data.GetBeaconBlockRoot()),
+						"source_epoch":      strconv.FormatUint(data.GetSource().GetEpoch(), 10),
+						"target_epoch":      strconv.FormatUint(data.GetTarget().GetEpoch(), 10),
+						"aggregation_bits":  hex.EncodeToString(attestation.AggregationBits),
+						"attesters":         strconv.FormatUint(attestation.AggregationBits.Count(), 10),
+					},
+				},
+			},
+		}
+	}
+
+	return transactions, nil
+}
+
+// intersectIndices returns the indices in both a and b,
+// which are sorted as attesting indices must be.
+func intersectIndices(a []uint64, b []uint64) []uint64 {
+	indices := []uint64{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			indices = append(indices, a[i])
+			i++
+			j++
+		}
+	}
+
+	return indices
+}
@@ -0,0 +1,159 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAttestation returns an attestation of slot by the
+// committee members set in aggregationBits.
+func testAttestation(slot uint64, aggregationBits []byte) *pb.Attestation {
+	return &pb.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &pb.AttestationData{
+			Slot:            slot,
+			CommitteeIndex:  1,
+			BeaconBlockRoot: bytes.Repeat([]byte{0x0b}, rootLength),
+			Source:          &pb.Checkpoint{Epoch: 2, Root: make([]byte, rootLength)},
+			Target:          &pb.Checkpoint{Epoch: 3, Root: make([]byte, rootLength)},
+		},
+		Signature: make([]byte, SignatureLength),
+	}
+}
+
+// testIndexedAttestation returns an attestation of
+// slot by the validators with indices.
+func testIndexedAttestation(slot uint64, indices ...uint64) *pb.IndexedAttestation {
+	attestation := testAttestation(slot, nil)
+	return &pb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             attestation.Data,
+		Signature:        attestation.Signature,
+	}
+}
+
+// testHeader returns a signed header of a block
+// proposed at slot by the validator with index.
+func testHeader(slot uint64, index uint64, bodyRoot byte) *pb.SignedBeaconBlockHeader {
+	return &pb.SignedBeaconBlockHeader{
+		Header: &pb.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: index,
+			ParentRoot:    make([]byte, rootLength),
+			StateRoot:     make([]byte, rootLength),
+			BodyRoot:      bytes.Repeat([]byte{bodyRoot}, rootLength),
+		},
+		Signature: make([]byte, SignatureLength),
+	}
+}
+
+func TestClient_BlockTransactions(t *testing.T) {
+	client := &Client{
+		network:    MainnetNetworkConfig,
+		validators: NewValidatorRegistry(&fakeRegistryClient{size: 8}, MainnetNetworkConfig, DefaultValidatorRegistrySize),
+	}
+
+	proposerSlashing := &pb.ProposerSlashing{
+		Header_1: testHeader(100, 4, 0x01),
+		Header_2: testHeader(100, 4, 0x02),
+	}
+	attesterSlashing := &pb.AttesterSlashing{
+		Attestation_1: testIndexedAttestation(90, 1, 2, 5),
+		Attestation_2: testIndexedAttestation(91, 2, 3, 5, 6),
+	}
+	attestation := testAttestation(99, []byte{0x0d})
+	exit := &pb.SignedVoluntaryExit{
+		Exit:      &pb.VoluntaryExit{Epoch: 3, ValidatorIndex: 7},
+		Signature: make([]byte, SignatureLength),
+	}
+
+	transactions, err := client.blockTransactions(context.Background(), &pb.BeaconBlockBody{
+		ProposerSlashings: []*pb.ProposerSlashing{proposerSlashing},
+		AttesterSlashings: []*pb.AttesterSlashing{attesterSlashing},
+		Attestations:      []*pb.Attestation{attestation},
+		VoluntaryExits:    []*pb.SignedVoluntaryExit{exit},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 4)
+
+	proposerSlashingRoot, err := proposerSlashing.HashTreeRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, &RosettaTypes.Transaction{
+		TransactionIdentifier: &RosettaTypes.TransactionIdentifier{Hash: operationHash(proposerSlashingRoot)},
+		Operations: []*RosettaTypes.Operation{
+			{
+				OperationIdentifier: &RosettaTypes.OperationIdentifier{Index: 0},
+				Type:                ProposerSlashingOpType,
+				Status:              RosettaTypes.String(SuccessStatus),
+				Account:             &RosettaTypes.AccountIdentifier{Address: ValidatorAddress(testPubkey(4))},
+				Metadata:            map[string]interface{}{"validator_index": "4", "slot": "100"},
+			},
+		},
+	}, transactions[0])
+
+	// Only validators attesting twice are slashed.
+	attesterSlashingRoot, err := attesterSlashing.HashTreeRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, operationHash(attesterSlashingRoot), transactions[1].TransactionIdentifier.Hash)
+	assert.Equal(t, []*RosettaTypes.Operation{
+		{
+			OperationIdentifier: &RosettaTypes.OperationIdentifier{Index: 0},
+			Type:                AttesterSlashingOpType,
+			Status:              RosettaTypes.String(SuccessStatus),
+			Account:             &RosettaTypes.AccountIdentifier{Address: ValidatorAddress(testPubkey(2))},
+			Metadata:            map[string]interface{}{"validator_index": "2"},
+		},
+		{
+			OperationIdentifier: &RosettaTypes.OperationIdentifier{Index: 1},
+			Type:                AttesterSlashingOpType,
+			Status:              RosettaTypes.String(SuccessStatus),
+			Account:             &RosettaTypes.AccountIdentifier{Address: ValidatorAddress(testPubkey(5))},
+			Metadata:            map[string]interface{}{"validator_index": "5"},
+		},
+	}, transactions[1].Operations)
+
+	attestationRoot, err := attestation.HashTreeRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, &RosettaTypes.Transaction{
+		TransactionIdentifier: &RosettaTypes.TransactionIdentifier{Hash: operationHash(attestationRoot)},
+		Operations: []*RosettaTypes.Operation{
+			{
+				OperationIdentifier: &RosettaTypes.OperationIdentifier{Index: 0},
+				Type:                AttestationOpType,
+				Status:              RosettaTypes.String(SuccessStatus),
+				Metadata: map[string]interface{}{
+					"slot":              "99",
+					"committee_index":   "1",
+					"beacon_block_root": "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+					"source_epoch":      "2",
+					"target_epoch":      "3",
+					"aggregation_bits":  "0d",
+					"attesters":         "2",
+				},
+			},
+		},
+	}, transactions[2])
+
+	exitRoot := VoluntaryExitRoot(exit.Exit)
+	assert.Equal(t, operationHash(exitRoot), transactions[3].TransactionIdentifier.Hash)
+
+	transactions, err = client.blockTransactions(context.Background(), &pb.BeaconBlockBody{})
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+
+	_, err = client.blockTransactions(context.Background(), &pb.BeaconBlockBody{
+		Attestations: []*pb.Attestation{{AggregationBits: []byte{0x01}, Data: &pb.AttestationData{}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestIntersectIndices(t *testing.T) {
+	assert.Equal(t, []uint64{2, 5}, intersectIndices([]uint64{1, 2, 5}, []uint64{2, 3, 5, 6}))
+	assert.Equal(t, []uint64{}, intersectIndices([]uint64{1, 3}, []uint64{2, 4}))
+	assert.Equal(t, []uint64{}, intersectIndices(nil, []uint64{2, 4}))
+}
@@ -0,0 +1,203 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// participationResult is the participation of validators
+// in the attestations of a completed epoch. Ether amounts
+// are in Gwei, as reported by the beacon node.
+type participationResult struct {
+	Epoch     string `json:"epoch"`
+	Finalized bool   `json:"finalized"`
+
+	// GlobalParticipationRate is the share of the eligible
+	// ether voting for the target checkpoint of the epoch.
+	GlobalParticipationRate float64 `json:"global_participation_rate"`
+
+	VotedEther           string `json:"voted_ether"`
+	EligibleEther        string `json:"eligible_ether"`
+	ActiveValidatorCount string `json:"active_validator_count"`
+
+	// TotalActiveBalance is the total effective balance of
+	// the active validators in the beacon state the epoch is
+	// read from, in which the epoch after it is the current
+	// epoch. Unlike EligibleEther, it includes the balance
+	// of validators activated since.
+	TotalActiveBalance string `json:"total_active_balance"`
+}
+
+// validatorQueue is the activation and exit
+// queue of participationCallResult.
+type validatorQueue struct {
+	ChurnLimit            string `json:"churn_limit"`
+	ActivationQueueLength string `json:"activation_queue_length"`
+	ExitQueueLength       string `json:"exit_queue_length"`
+}
+
+// participationCallResult is the result of participation.
+// The validator queue is the one of the chain head, as
+// the beacon node does not serve it for past epochs.
+type participationCallResult struct {
+	*participationResult
+
+	ValidatorQueue *validatorQueue `json:"validator_queue"`
+}
+
+// participation implements participation.
+func (ec *Client) participation(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*participationCallResult, error) {
+	var params epochParameters
+	if err := decodeCallParameters(parameters, &params); err != nil {
+		return nil, err
+	}
+
+	epoch, err := ec.participationEpoch(ctx, params.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	participation, err := ec.epochParticipation(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := ec.beaconChainClient.GetValidatorQueue(ctx, &types.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get validator queue", err)
+	}
+
+	return &participationCallResult{
+		participationResult: participation,
+		ValidatorQueue: &validatorQueue{
+			ChurnLimit:            strconv.FormatUint(queue.ChurnLimit, 10),
+			ActivationQueueLength: strconv.Itoa(len(queue.ActivationValidatorIndices)),
+			ExitQueueLength:       strconv.Itoa(len(queue.ExitValidatorIndices)),
+		},
+	}, nil
+}
+
+// participationEpoch returns the epoch of the epoch parameter
+// of participation, or the epoch before the chain head. Only
+// the participation of completed epochs is known.
+func (ec *Client) participationEpoch(ctx context.Context, epochParameter string) (uint64, error) {
+	chainHead, err := ec.chainHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	headEpoch := chainHead.HeadSlot / ec.network.Preset.SlotsPerEpoch
+	if len(epochParameter) == 0 {
+		if headEpoch == 0 {
+			return 0, fmt.Errorf("%w: no epoch is complete, the head is at epoch 0", ErrCallParametersInvalid)
+		}
+
+		return headEpoch - 1, nil
+	}
+
+	epoch, err := parseUintParameter("epoch", epochParameter)
+	if err != nil {
+		return 0, err
+	}
+
+	if epoch >= headEpoch {
+		return 0, fmt.Errorf(
+			"%w: participation of epoch %d is not known before epoch %d, the head is at epoch %d",
+			ErrCallParametersInvalid,
+			epoch,
+			epoch+1,
+			headEpoch,
+		)
+	}
+
+	return epoch, nil
+}
+
+// epochParticipation returns the participation of
+// validators in the attestations of epoch.
+func (ec *Client) epochParticipation(ctx context.Context, epoch uint64) (*participationResult, error) {
+	participationReq := &pb.GetValidatorParticipationRequest{
+		QueryFilter: &pb.GetValidatorParticipationRequest_Epoch{Epoch: epoch},
+	}
+	validatorsReq := &pb.ListValidatorsRequest{
+		QueryFilter: &pb.ListValidatorsRequest_Epoch{Epoch: epoch},
+		Active:      true,
+		PageSize:    1,
+	}
+
+	// The beacon node reads epoch 0 as the current
+	// epoch, so genesis is queried explicitly.
+	if epoch == 0 {
+		participationReq.QueryFilter = &pb.GetValidatorParticipationRequest_Genesis{Genesis: true}
+		validatorsReq.QueryFilter = &pb.ListValidatorsRequest_Genesis{Genesis: true}
+	}
+
+	res, err := ec.beaconChainClient.GetValidatorParticipation(ctx, participationReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get validator participation of epoch %d", err, epoch)
+	}
+
+	validators, err := ec.beaconChainClient.ListValidators(ctx, validatorsReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not count active validators of epoch %d", err, epoch)
+	}
+
+	participation := res.GetParticipation()
+	voted := participation.GetPreviousEpochTargetAttestingGwei()
+	eligible := participation.GetPreviousEpochActiveGwei()
+
+	rate := float64(0)
+	if eligible > 0 {
+		rate = float64(voted) / float64(eligible)
+	}
+
+	return &participationResult{
+		Epoch:                   strconv.FormatUint(res.Epoch, 10),
+		Finalized:               res.Finalized,
+		GlobalParticipationRate: rate,
+		VotedEther:              strconv.FormatUint(voted, 10),
+		EligibleEther:           strconv.FormatUint(eligible, 10),
+		ActiveValidatorCount:    strconv.FormatUint(uint64(validators.TotalSize), 10),
+		TotalActiveBalance:      strconv.FormatUint(participation.GetCurrentEpochActiveGwei(), 10),
+	}, nil
+}
+
+// boundaryParticipation returns the participation metadata of
+// the block at slot with a parent at parentSlot: the first block
+// of every epoch but the genesis epoch reports the participation
+// of the previous epoch. It returns nil for every other block
+// or when participation metadata is not enabled.
+//
+// Finality is left out as block metadata must not change once
+// served; it is only reported by the participation method.
+func (ec *Client) boundaryParticipation(
+	ctx context.Context,
+	slot uint64,
+	parentSlot uint64,
+) (map[string]interface{}, error) {
+	epoch := slot / ec.network.Preset.SlotsPerEpoch
+	if !ec.participationMetadata || epoch == 0 || parentSlot/ec.network.Preset.SlotsPerEpoch == epoch {
+		return nil, nil
+	}
+
+	participation, err := ec.epochParticipation(ctx, epoch-1)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"epoch":                     participation.Epoch,
+		"global_participation_rate": participation.GlobalParticipationRate,
+		"voted_ether":               participation.VotedEther,
+		"eligible_ether":            participation.EligibleEther,
+		"active_validator_count":    participation.ActiveValidatorCount,
+		"total_active_balance":      participation.TotalActiveBalance,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+)
+
+func (c *fakeCallChainClient) GetValidatorParticipation(
+	ctx context.Context,
+	req *pb.GetValidatorParticipationRequest,
+	opts ...grpc.CallOption,
+) (*pb.ValidatorParticipationResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	epoch := req.GetEpoch()
+	return &pb.ValidatorParticipationResponse{
+		Epoch:     epoch,
+		Finalized: epoch <= 98,
+		Participation: &pb.ValidatorParticipation{
+			CurrentEpochActiveGwei:           224000000000,
+			PreviousEpochActiveGwei:          192000000000,
+			PreviousEpochTargetAttestingGwei: 144000000000,
+		},
+	}, nil
+}
+
+func (c *fakeCallChainClient) ListValidators(
+	ctx context.Context,
+	req *pb.ListValidatorsRequest,
+	opts ...grpc.CallOption,
+) (*pb.Validators, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if !req.Active {
+		return nil, errors.New("unexpected request of inactive validators")
+	}
+
+	return &pb.Validators{
+		Epoch:     req.GetEpoch(),
+		TotalSize: 6,
+	}, nil
+}
+
+func (c *fakeCallChainClient) GetValidatorQueue(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*pb.ValidatorQueue, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &pb.ValidatorQueue{
+		ChurnLimit:                 4,
+		ActivationValidatorIndices: []uint64{6, 7},
+	}, nil
+}
+
+func TestClient_BoundaryParticipation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		disabled   bool
+		slot       uint64
+		parentSlot uint64
+		chainErr   error
+
+		expected    map[string]interface{}
+		expectedErr error
+	}{
+		"first slot of an epoch": {
+			slot:       3200,
+			parentSlot: 3199,
+			expected: map[string]interface{}{
+				"epoch":                     "99",
+				"global_participation_rate": 0.75,
+				"voted_ether":               "144000000000",
+				"eligible_ether":            "192000000000",
+				"active_validator_count":    "6",
+				"total_active_balance":      "224000000000",
+			},
+		},
+		"first block after skipped slots": {
+			slot:       3170,
+			parentSlot: 3135,
+			expected: map[string]interface{}{
+				"epoch":                     "98",
+				"global_participation_rate": 0.75,
+				"voted_ether":               "144000000000",
+				"eligible_ether":            "192000000000",
+				"active_validator_count":    "6",
+				"total_active_balance":      "224000000000",
+			},
+		},
+		"second block of an epoch": {
+			slot:       3201,
+			parentSlot: 3200,
+		},
+		"genesis": {
+			slot:       0,
+			parentSlot: 0,
+		},
+		"disabled": {
+			disabled:   true,
+			slot:       3200,
+			parentSlot: 3199,
+		},
+		"beacon node error": {
+			slot:        3200,
+			parentSlot:  3199,
+			chainErr:    errors.New("connection refused"),
+			expectedErr: errors.New("connection refused"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &Client{
+				network:               MainnetNetworkConfig,
+				beaconChainClient:     &fakeCallChainClient{err: test.chainErr},
+				participationMetadata: !test.disabled,
+			}
+
+			participation, err := client.boundaryParticipation(ctx, test.slot, test.parentSlot)
+			if test.expectedErr != nil {
+				assert.Contains(t, err.Error(), test.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, participation)
+		})
+	}
+}
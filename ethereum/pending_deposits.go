@@ -0,0 +1,293 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	// depositEventName is the name of the event logged
+	// by the deposit contract for every deposit.
+	depositEventName = "DepositEvent"
+
+	// depositEventUintLength is the length of the little-endian
+	// amount and index of a deposit event.
+	depositEventUintLength = 8
+)
+
+var (
+	// ErrDepositEventInvalid is returned when a deposit
+	// contract log is not a well-formed DepositEvent.
+	ErrDepositEventInvalid = errors.New("deposit event invalid")
+)
+
+// PendingDeposit is a deposit to the deposit contract that
+// the beacon node has seen but not processed into the beacon
+// state yet.
+type PendingDeposit struct {
+	// TransactionHash is the hash of the
+	// transaction funding the deposit.
+	TransactionHash common.Hash
+
+	Pubkey                []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Index                 uint64
+	Eth1BlockNumber       uint64
+
+	// EstimatedInclusionEpoch is the epoch the deposit is
+	// expected to be processed in, assuming eth1 votes reach
+	// a majority halfway through a voting period and every
+	// block includes MaxDeposits deposits.
+	EstimatedInclusionEpoch uint64
+}
+
+// PendingDeposits returns the deposits the beacon node has
+// seen from the deposit contract but not processed, ordered
+// by deposit index.
+//
+// Deposits are read from the logs of the deposit contract
+// through the web3 provider and kept when the beacon node
+// reports their validator as deposited but not yet in the
+// registry. Top-ups of registered validators cannot be told
+// apart from processed deposits and are left out.
+func (ec *Client) PendingDeposits(ctx context.Context) ([]*PendingDeposit, error) {
+	ctx, span := tracer.Start(ctx, "Client.PendingDeposits")
+	defer span.End()
+
+	if ec.execution == nil {
+		return nil, ErrWeb3ProviderUnavailable
+	}
+
+	contract, err := ec.DepositContract(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := ec.execution.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get eth1 head", err)
+	}
+
+	from := uint64(0)
+	if lookback := depositLookback(ec.network.Preset); head.Number.Uint64() > lookback {
+		from = head.Number.Uint64() - lookback
+	}
+
+	logs, err := ec.execution.FilterLogs(ctx, geth.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   head.Number,
+		Addresses: []common.Address{contract},
+		Topics:    [][]common.Hash{{depositContract.Events[depositEventName].ID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get deposit events", err)
+	}
+
+	deposits := []*PendingDeposit{}
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+
+		deposit, err := parseDepositEvent(log)
+		if err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, deposit)
+	}
+	if len(deposits) == 0 {
+		return deposits, nil
+	}
+	sort.Slice(deposits, func(i, j int) bool {
+		return deposits[i].Index < deposits[j].Index
+	})
+
+	pending, err := ec.unprocessedDeposits(ctx, deposits)
+	if err != nil {
+		return nil, err
+	}
+
+	chainHead, err := ec.chainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch := chainHead.HeadSlot / ec.network.Preset.SlotsPerEpoch
+	for position, deposit := range pending {
+		deposit.EstimatedInclusionEpoch = ec.estimateInclusionEpoch(deposit, head, epoch, uint64(position))
+	}
+
+	return pending, nil
+}
+
+// unprocessedDeposits returns the deposits of validators
+// the beacon node has seen deposits for but has not added
+// to the registry.
+func (ec *Client) unprocessedDeposits(
+	ctx context.Context,
+	deposits []*PendingDeposit,
+) ([]*PendingDeposit, error) {
+	pubkeys := [][]byte{}
+	requested := map[string]bool{}
+	for _, deposit := range deposits {
+		if !requested[string(deposit.Pubkey)] {
+			requested[string(deposit.Pubkey)] = true
+			pubkeys = append(pubkeys, deposit.Pubkey)
+		}
+	}
+
+	res, err := ec.validatorClient.MultipleValidatorStatus(ctx, &pb.MultipleValidatorStatusRequest{
+		PublicKeys: pubkeys,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get validator statuses", err)
+	}
+
+	unprocessed := map[string]bool{}
+	for i, status := range res.Statuses {
+		if i >= len(res.PublicKeys) {
+			break
+		}
+
+		switch status.GetStatus() {
+		case pb.ValidatorStatus_DEPOSITED, pb.ValidatorStatus_PARTIALLY_DEPOSITED:
+			unprocessed[string(res.PublicKeys[i])] = true
+		}
+	}
+
+	pending := []*PendingDeposit{}
+	for _, deposit := range deposits {
+		if unprocessed[string(deposit.Pubkey)] {
+			pending = append(pending, deposit)
+		}
+	}
+
+	return pending, nil
+}
+
+// estimateInclusionEpoch returns the epoch in which deposit,
+// at position in the queue of pending deposits, is expected
+// to be processed given the eth1 head and the current epoch.
+func (ec *Client) estimateInclusionEpoch(
+	deposit *PendingDeposit,
+	head *gethTypes.Header,
+	epoch uint64,
+	position uint64,
+) uint64 {
+	preset := ec.network.Preset
+
+	// The time of the deposit block is estimated from
+	// the head rather than queried for every deposit.
+	blockTime := int64(head.Time)
+	if depth := head.Number.Uint64(); depth > deposit.Eth1BlockNumber {
+		blockTime -= int64((depth - deposit.Eth1BlockNumber) * preset.SecondsPerEth1Block)
+	}
+
+	// Eth1 data is voted on during the first voting period
+	// starting after the deposit block is followed.
+	followed := blockTime + int64(preset.Eth1FollowDistance*preset.SecondsPerEth1Block)
+	period := preset.EpochsPerEth1VotingPeriod
+	votable := ec.network.Epoch(time.Unix(followed, 0))
+	voted := (votable+period-1)/period*period + period/2
+	if voted < epoch {
+		voted = epoch
+	}
+
+	return voted + position/(preset.MaxDeposits*preset.SlotsPerEpoch)
+}
+
+// depositLookback returns the number of eth1 blocks searched
+// for pending deposits: twice the time for a deposit to be
+// followed and voted on, leaving room for a deposit queue.
+func depositLookback(preset *Preset) uint64 {
+	votingPeriod := preset.EpochsPerEth1VotingPeriod * preset.SlotsPerEpoch * preset.SecondsPerSlot
+	return 2 * (preset.Eth1FollowDistance + votingPeriod/preset.SecondsPerEth1Block)
+}
+
+// parseDepositEvent parses a DepositEvent
+// logged by the deposit contract.
+func parseDepositEvent(log gethTypes.Log) (*PendingDeposit, error) {
+	values, err := depositContract.Unpack(depositEventName, log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrDepositEventInvalid, log.TxHash.Hex(), err.Error())
+	}
+
+	fields := make([][]byte, len(values))
+	for i, value := range values {
+		fields[i], _ = value.([]byte)
+	}
+	if len(fields) != 5 || len(fields[2]) != depositEventUintLength || len(fields[4]) != depositEventUintLength {
+		return nil, fmt.Errorf("%w: %s", ErrDepositEventInvalid, log.TxHash.Hex())
+	}
+
+	return &PendingDeposit{
+		TransactionHash:       log.TxHash,
+		Pubkey:                fields[0],
+		WithdrawalCredentials: fields[1],
+		Amount:                binary.LittleEndian.Uint64(fields[2]),
+		Index:                 binary.LittleEndian.Uint64(fields[4]),
+		Eth1BlockNumber:       log.BlockNumber,
+	}, nil
+}
+
+// pendingDeposit is a deposit of pendingDepositsResult.
+// The amount is in Gwei.
+type pendingDeposit struct {
+	TransactionHash         string `json:"transaction_hash"`
+	PublicKey               string `json:"public_key"`
+	WithdrawalCredentials   string `json:"withdrawal_credentials"`
+	Amount                  string `json:"amount"`
+	DepositIndex            string `json:"deposit_index"`
+	Eth1BlockNumber         string `json:"eth1_block_number"`
+	EstimatedInclusionEpoch string `json:"estimated_inclusion_epoch"`
+}
+
+// pendingDepositsResult is the result of pending_deposits.
+type pendingDepositsResult struct {
+	Deposits []*pendingDeposit `json:"deposits"`
+}
+
+// pendingDeposits implements pending_deposits. Pending deposits
+// are not served from /mempool as they never appear in /block
+// under the hash of the transaction funding them.
+func (ec *Client) pendingDeposits(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*pendingDepositsResult, error) {
+	if err := decodeCallParameters(parameters, &struct{}{}); err != nil {
+		return nil, err
+	}
+
+	deposits, err := ec.PendingDeposits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pendingDepositsResult{Deposits: make([]*pendingDeposit, len(deposits))}
+	for i, deposit := range deposits {
+		result.Deposits[i] = &pendingDeposit{
+			TransactionHash:         deposit.TransactionHash.Hex(),
+			PublicKey:               ValidatorAddress(deposit.Pubkey),
+			WithdrawalCredentials:   "0x" + hex.EncodeToString(deposit.WithdrawalCredentials),
+			Amount:                  strconv.FormatUint(deposit.Amount, 10),
+			DepositIndex:            strconv.FormatUint(deposit.Index, 10),
+			Eth1BlockNumber:         strconv.FormatUint(deposit.Eth1BlockNumber, 10),
+			EstimatedInclusionEpoch: strconv.FormatUint(deposit.EstimatedInclusionEpoch, 10),
+		}
+	}
+
+	return result, nil
+}
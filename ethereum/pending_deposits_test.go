@@ -0,0 +1,302 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	types "github.com/gogo/protobuf/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+)
+
+const (
+	// testEth1Head is the eth1 head block
+	// number of the pending deposit tests.
+	testEth1Head = 12000000
+
+	// testDepositEpoch is the head epoch of
+	// the pending deposit tests.
+	testDepositEpoch = 1000
+)
+
+// fakeDepositChainClient serves the beacon config, a
+// head at testDepositEpoch and an empty attestation pool.
+type fakeDepositChainClient struct {
+	fakeAttestationPoolClient
+}
+
+func (c *fakeDepositChainClient) GetChainHead(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*pb.ChainHead, error) {
+	return &pb.ChainHead{HeadSlot: testDepositEpoch * 32}, nil
+}
+
+func (c *fakeDepositChainClient) GetBeaconConfig(
+	context.Context,
+	*types.Empty,
+	...grpc.CallOption,
+) (*pb.BeaconConfig, error) {
+	return &pb.BeaconConfig{Config: map[string]string{
+		"DepositContractAddress": "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+	}}, nil
+}
+
+// fakeStatusClient answers MultipleValidatorStatus with
+// statuses, UNKNOWN_STATUS by default, or with err when set.
+type fakeStatusClient struct {
+	pb.BeaconNodeValidatorClient
+
+	statuses map[string]pb.ValidatorStatus
+	err      error
+}
+
+func (c *fakeStatusClient) MultipleValidatorStatus(
+	ctx context.Context,
+	req *pb.MultipleValidatorStatusRequest,
+	opts ...grpc.CallOption,
+) (*pb.MultipleValidatorStatusResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	res := &pb.MultipleValidatorStatusResponse{PublicKeys: req.PublicKeys}
+	for _, pubkey := range req.PublicKeys {
+		res.Statuses = append(res.Statuses, &pb.ValidatorStatusResponse{
+			Status: c.statuses[string(pubkey)],
+		})
+	}
+
+	return res, nil
+}
+
+// testDepositHead returns the eth1 head, mined
+// at the start of epoch testDepositEpoch.
+func testDepositHead() *gethTypes.Header {
+	return &gethTypes.Header{
+		Number: big.NewInt(testEth1Head),
+		Time:   uint64(MainnetNetworkConfig.GenesisTime + testDepositEpoch*12*32),
+	}
+}
+
+// testDepositLog returns the DepositEvent of the
+// deposit at index of 32 ETH to validator.
+func testDepositLog(t *testing.T, index uint64, validator uint64, block uint64) gethTypes.Log {
+	amount := make([]byte, depositEventUintLength)
+	binary.LittleEndian.PutUint64(amount, 32000000000)
+	depositIndex := make([]byte, depositEventUintLength)
+	binary.LittleEndian.PutUint64(depositIndex, index)
+
+	data, err := depositContract.Events[depositEventName].Inputs.Pack(
+		testPubkey(validator),
+		testCredentials(validator),
+		amount,
+		make([]byte, SignatureLength),
+		depositIndex,
+	)
+	assert.NoError(t, err)
+
+	return gethTypes.Log{
+		Address:     common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+		Topics:      []common.Hash{depositContract.Events[depositEventName].ID},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(index + 1)),
+	}
+}
+
+func TestClient_PendingDeposits(t *testing.T) {
+	ctx := context.Background()
+	statuses := map[string]pb.ValidatorStatus{
+		string(testPubkey(1)): pb.ValidatorStatus_DEPOSITED,
+		string(testPubkey(2)): pb.ValidatorStatus_ACTIVE,
+		string(testPubkey(3)): pb.ValidatorStatus_PARTIALLY_DEPOSITED,
+	}
+	removed := testDepositLog(t, 3, 1, testEth1Head)
+	removed.Removed = true
+	malformed := testDepositLog(t, 4, 1, testEth1Head)
+	malformed.Data = malformed.Data[:64]
+
+	tests := map[string]struct {
+		execution *fakeExecutionClient
+		status    *fakeStatusClient
+
+		expected    []*PendingDeposit
+		expectedErr error
+	}{
+		"pending deposits": {
+			execution: &fakeExecutionClient{
+				head: testDepositHead(),
+				logs: []gethTypes.Log{
+					testDepositLog(t, 2, 3, testEth1Head-100),
+					testDepositLog(t, 1, 2, testEth1Head-2000),
+					testDepositLog(t, 0, 1, testEth1Head-4000),
+					removed,
+				},
+			},
+			status: &fakeStatusClient{statuses: statuses},
+			expected: []*PendingDeposit{
+				{
+					// Voted on before the head epoch.
+					TransactionHash:         common.BigToHash(big.NewInt(1)),
+					Pubkey:                  testPubkey(1),
+					WithdrawalCredentials:   testCredentials(1),
+					Amount:                  32000000000,
+					Index:                   0,
+					Eth1BlockNumber:         testEth1Head - 4000,
+					EstimatedInclusionEpoch: testDepositEpoch,
+				},
+				{
+					// Followed in epoch 1071 and voted on
+					// halfway through the next voting period.
+					TransactionHash:         common.BigToHash(big.NewInt(3)),
+					Pubkey:                  testPubkey(3),
+					WithdrawalCredentials:   testCredentials(3),
+					Amount:                  32000000000,
+					Index:                   2,
+					Eth1BlockNumber:         testEth1Head - 100,
+					EstimatedInclusionEpoch: 1120,
+				},
+			},
+		},
+		"no deposits": {
+			execution: &fakeExecutionClient{head: testDepositHead()},
+			status:    &fakeStatusClient{err: errors.New("unexpected status request")},
+			expected:  []*PendingDeposit{},
+		},
+		"deposits unknown to the beacon node": {
+			execution: &fakeExecutionClient{
+				head: testDepositHead(),
+				logs: []gethTypes.Log{testDepositLog(t, 0, 4, testEth1Head-100)},
+			},
+			status:   &fakeStatusClient{statuses: statuses},
+			expected: []*PendingDeposit{},
+		},
+		"malformed deposit event": {
+			execution: &fakeExecutionClient{
+				head: testDepositHead(),
+				logs: []gethTypes.Log{malformed},
+			},
+			status:      &fakeStatusClient{statuses: statuses},
+			expectedErr: ErrDepositEventInvalid,
+		},
+		"web3 provider error": {
+			execution:   &fakeExecutionClient{err: errors.New("connection refused")},
+			status:      &fakeStatusClient{statuses: statuses},
+			expectedErr: errors.New("connection refused"),
+		},
+		"validator status error": {
+			execution: &fakeExecutionClient{
+				head: testDepositHead(),
+				logs: []gethTypes.Log{testDepositLog(t, 0, 1, testEth1Head-100)},
+			},
+			status:      &fakeStatusClient{err: errors.New("connection reset")},
+			expectedErr: errors.New("connection reset"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &Client{
+				network:           MainnetNetworkConfig,
+				beaconChainClient: &fakeDepositChainClient{},
+				validatorClient:   test.status,
+				execution:         test.execution,
+			}
+
+			deposits, err := client.PendingDeposits(ctx)
+			switch {
+			case test.expectedErr == nil:
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, deposits)
+			case errors.Is(test.expectedErr, ErrDepositEventInvalid):
+				assert.True(t, errors.Is(err, ErrDepositEventInvalid))
+				assert.Nil(t, deposits)
+			default:
+				assert.Contains(t, err.Error(), test.expectedErr.Error())
+				assert.Nil(t, deposits)
+			}
+		})
+	}
+
+	t.Run("lookback", func(t *testing.T) {
+		execution := &fakeExecutionClient{head: testDepositHead()}
+		client := &Client{
+			network:           MainnetNetworkConfig,
+			beaconChainClient: &fakeDepositChainClient{},
+			execution:         execution,
+		}
+
+		_, err := client.PendingDeposits(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, execution.queries, 1)
+		assert.Equal(t, big.NewInt(testEth1Head-7606), execution.queries[0].FromBlock)
+		assert.Equal(t, big.NewInt(testEth1Head), execution.queries[0].ToBlock)
+		assert.Equal(t, []common.Address{
+			common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+		}, execution.queries[0].Addresses)
+	})
+
+	t.Run("no web3 provider", func(t *testing.T) {
+		client := &Client{network: MainnetNetworkConfig}
+
+		deposits, err := client.PendingDeposits(ctx)
+		assert.True(t, errors.Is(err, ErrWeb3ProviderUnavailable))
+		assert.Nil(t, deposits)
+	})
+}
+
+func TestClient_CallPendingDeposits(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{
+		network:           MainnetNetworkConfig,
+		beaconChainClient: &fakeDepositChainClient{},
+		validatorClient: &fakeStatusClient{statuses: map[string]pb.ValidatorStatus{
+			string(testPubkey(1)): pb.ValidatorStatus_DEPOSITED,
+		}},
+		execution: &fakeExecutionClient{
+			head: testDepositHead(),
+			logs: []gethTypes.Log{testDepositLog(t, 5, 1, testEth1Head-100)},
+		},
+	}
+
+	response, err := client.Call(ctx, &RosettaTypes.CallRequest{Method: PendingDepositsMethod})
+	assert.NoError(t, err)
+	assert.Equal(t, &RosettaTypes.CallResponse{
+		Result: map[string]interface{}{
+			"deposits": []interface{}{
+				map[string]interface{}{
+					"transaction_hash":          common.BigToHash(big.NewInt(6)).Hex(),
+					"public_key":                ValidatorAddress(testPubkey(1)),
+					"withdrawal_credentials":    "0x0000000000000000000000000000000000000000000000000000000000000001",
+					"amount":                    "32000000000",
+					"deposit_index":             "5",
+					"eth1_block_number":         "11999900",
+					"estimated_inclusion_epoch": "1120",
+				},
+			},
+		},
+	}, response)
+
+	// Pending deposits are not part of the mempool.
+	client.poolClient = &fakePoolClient{}
+	identifiers, err := client.Mempool(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, identifiers)
+
+	t.Run("no web3 provider", func(t *testing.T) {
+		client := &Client{network: MainnetNetworkConfig}
+
+		response, err := client.Call(ctx, &RosettaTypes.CallRequest{Method: PendingDepositsMethod})
+		assert.True(t, errors.Is(err, ErrWeb3ProviderUnavailable))
+		assert.Nil(t, response)
+	})
+}
@@ -3,73 +3,274 @@ package ethereum
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
-	"golang.org/x/sync/errgroup"
+	"rosetta-ethereum-2.0/timeutils"
 )
 
 const (
 	prysmLogger       = "prysm"
 	prysmStdErrLogger = "prysm err"
+
+	// PrysmBinary is the location of the beacon-chain
+	// binary in the docker image.
+	PrysmBinary = "/app/beacon-chain"
+
+	// prysmShutdownGracePeriod is how long prysm may take to
+	// exit after receiving SIGINT before it is sent SIGKILL.
+	prysmShutdownGracePeriod = 30 * time.Second
+
+	// prysmMinRestartBackoff is the delay before the first
+	// restart after a crash. It doubles on every consecutive
+	// crash up to prysmMaxRestartBackoff.
+	prysmMinRestartBackoff = 1 * time.Second
+
+	// prysmMaxRestartBackoff is the maximum delay
+	// between restarts.
+	prysmMaxRestartBackoff = 1 * time.Minute
+
+	// prysmRestartWindow is the window over which crashes are
+	// counted. A process that stays up longer than this also
+	// resets the backoff.
+	prysmRestartWindow = 10 * time.Minute
+
+	// prysmMaxRestarts is the maximum number of crashes allowed
+	// within prysmRestartWindow before the supervisor gives up.
+	prysmMaxRestarts = 5
 )
 
-// logPipe prints out logs from prysm. We don't end when context
-// is canceled beacause there are often logs printed after this.
-func logPipe(pipe io.ReadCloser, identifier string) error {
-	reader := bufio.NewReader(pipe)
+// ErrPrysmRestartLimit is returned when prysm crashes more
+// often than the supervisor is allowed to restart it.
+var ErrPrysmRestartLimit = errors.New("prysm restart limit exceeded")
+
+// PrysmStatus is a snapshot of the supervised prysm process.
+// Peers, SyncSlot and ChainStarted are recognized from the logs
+// of the current process. DatabaseError is the last fatal
+// database error, kept across restarts until a process gets
+// the chain started.
+type PrysmStatus struct {
+	Running        bool   `json:"running"`
+	Restarts       int    `json:"restarts"`
+	LastExitStatus string `json:"last_exit_status,omitempty"`
+	LastExitTime   int64  `json:"last_exit_time,omitempty"`
+	Peers          int    `json:"peers"`
+	SyncSlot       int64  `json:"sync_slot"`
+	ChainStarted   bool   `json:"chain_started"`
+	DatabaseError  string `json:"database_error,omitempty"`
+}
+
+// PrysmSupervisor runs prysm and restarts it with
+// backoff whenever it exits unexpectedly.
+type PrysmSupervisor struct {
+	binary    string
+	arguments []string
+
+	gracePeriod   time.Duration
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	restartWindow time.Duration
+	maxRestarts   int
+
+	statusMutex sync.Mutex
+	status      PrysmStatus
+}
+
+// NewPrysmSupervisor creates a new instance of a PrysmSupervisor.
+func NewPrysmSupervisor(binary string, arguments []string) *PrysmSupervisor {
+	return &PrysmSupervisor{
+		binary:        binary,
+		arguments:     arguments,
+		gracePeriod:   prysmShutdownGracePeriod,
+		minBackoff:    prysmMinRestartBackoff,
+		maxBackoff:    prysmMaxRestartBackoff,
+		restartWindow: prysmRestartWindow,
+		maxRestarts:   prysmMaxRestarts,
+	}
+}
+
+// Status returns a snapshot of the supervised process.
+func (s *PrysmSupervisor) Status() *PrysmStatus {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	status := s.status
+	return &status
+}
+
+// Run starts prysm and blocks until ctx is canceled, restarting
+// prysm whenever it exits on its own. It returns nil after a
+// graceful shutdown and ErrPrysmRestartLimit when prysm crashes
+// too often.
+func (s *PrysmSupervisor) Run(ctx context.Context) error {
+	backoff := s.minBackoff
+	crashes := []time.Time{}
 	for {
-		str, err := reader.ReadString('\n')
+		started := timeutils.Now()
+		exitStatus, err := s.runOnce(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
-			log.Println("closing", identifier, err)
 			return err
 		}
 
-		message := strings.ReplaceAll(str, "\n", "")
-		log.Println(identifier, message)
+		now := timeutils.Now()
+		s.statusMutex.Lock()
+		s.status.LastExitStatus = exitStatus
+		s.status.LastExitTime = now.Unix() * 1000
+		s.statusMutex.Unlock()
+
+		recentCrashes := []time.Time{now}
+		for _, crash := range crashes {
+			if now.Sub(crash) < s.restartWindow {
+				recentCrashes = append(recentCrashes, crash)
+			}
+		}
+		crashes = recentCrashes
+		if len(crashes) > s.maxRestarts {
+			return fmt.Errorf(
+				"%w: %d crashes within %s, last %s",
+				ErrPrysmRestartLimit,
+				len(crashes),
+				s.restartWindow,
+				exitStatus,
+			)
+		}
+
+		if now.Sub(started) > s.restartWindow {
+			backoff = s.minBackoff
+		}
+
+		log.Printf("prysm exited unexpectedly (%s), restarting in %s", exitStatus, backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+
+		s.statusMutex.Lock()
+		s.status.Restarts++
+		s.statusMutex.Unlock()
 	}
 }
 
-// StartPrysm starts a prysm daemon in another goroutine
-// and logs the results to the console.
-func StartPrysm(ctx context.Context, arguments string, g *errgroup.Group) error {
-	parsedArgs := strings.Split(arguments, " ")
-	cmd := exec.Command(
-		"/app/beacon-chain",
-		parsedArgs...)
+// runOnce starts prysm and waits for it to exit. When ctx is
+// canceled prysm is sent SIGINT, escalated to SIGKILL after the
+// grace period. The returned error is only populated when prysm
+// could not be started.
+func (s *PrysmSupervisor) runOnce(ctx context.Context) (string, error) {
+	cmd := exec.Command(s.binary, s.arguments...)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	g.Go(func() error {
-		return logPipe(stdout, prysmLogger)
-	})
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("%w: unable to start prysm", err)
+	}
+	s.statusMutex.Lock()
+	s.status.Running = true
+	s.status.Peers = 0
+	s.status.SyncSlot = 0
+	s.status.ChainStarted = false
+	s.statusMutex.Unlock()
+	defer s.setRunning(false)
 
-	g.Go(func() error {
-		return logPipe(stderr, prysmStdErrLogger)
-	})
+	// The pipes must be drained before calling
+	// Wait, which closes them.
+	done := make(chan struct{})
+	go func() {
+		var pipes sync.WaitGroup
+		pipes.Add(2)
+		go func() {
+			defer pipes.Done()
+			_ = logPipe(stdout, prysmLogger, s.observe)
+		}()
+		go func() {
+			defer pipes.Done()
+			_ = logPipe(stderr, prysmStdErrLogger, s.observe)
+		}()
+		pipes.Wait()
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("%w: unable to start prysm", err)
+		_ = cmd.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return cmd.ProcessState.String(), nil
+	case <-ctx.Done():
+	}
+
+	log.Println("sending interrupt to prysm")
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		log.Println("unable to interrupt prysm", err)
 	}
 
-	g.Go(func() error {
-		<-ctx.Done()
+	select {
+	case <-done:
+	case <-time.After(s.gracePeriod):
+		log.Printf("prysm did not exit within %s, sending kill", s.gracePeriod)
+		if err := cmd.Process.Kill(); err != nil {
+			log.Println("unable to kill prysm", err)
+		}
+		<-done
+	}
+
+	return cmd.ProcessState.String(), nil
+}
+
+func (s *PrysmSupervisor) setRunning(running bool) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+
+	s.status.Running = running
+}
 
-		log.Println("sending interrupt to prysm")
-		return cmd.Process.Signal(os.Interrupt)
-	})
+func (s *PrysmSupervisor) observe(entry *PrysmLogEntry) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
 
-	return cmd.Wait()
+	s.status.observe(entry)
+}
+
+// logPipe parses logs from prysm, re-emits them in logfmt and
+// passes each entry to handle. We don't end when context is
+// canceled beacause there are often logs printed after this.
+func logPipe(pipe io.ReadCloser, identifier string, handle func(*PrysmLogEntry)) error {
+	reader := bufio.NewReader(pipe)
+	for {
+		str, err := reader.ReadString('\n')
+		if message := strings.TrimSpace(str); len(message) > 0 {
+			entry := parsePrysmLog(message)
+			log.Println(identifier, entry)
+			handle(entry)
+		}
+		if err != nil {
+			log.Println("closing", identifier, err)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
 }
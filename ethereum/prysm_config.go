@@ -0,0 +1,157 @@
+package ethereum
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// PrysmConfigFile is the location of the prysm
+	// configuration file in the docker image.
+	PrysmConfigFile = "/app/ethereum/prysm-config.yaml"
+)
+
+var (
+	// ErrPrysmBinaryMissing is returned when no prysm
+	// binary is configured.
+	ErrPrysmBinaryMissing = errors.New("prysm binary must be populated")
+
+	// ErrPrysmDataDirMissing is returned when no prysm
+	// data directory is configured.
+	ErrPrysmDataDirMissing = errors.New("prysm datadir must be populated")
+
+	// ErrPrysmNetworkFlagInvalid is returned when the
+	// network flags do not start with a flag.
+	ErrPrysmNetworkFlagInvalid = errors.New("prysm network flag invalid")
+
+	// ErrWeb3ProviderMissing is returned when no web3
+	// provider is configured.
+	ErrWeb3ProviderMissing = errors.New("at least one web3 provider must be populated")
+
+	// ErrWeb3ProviderInvalid is returned when a web3
+	// provider is not an http(s) or ws(s) URL.
+	ErrWeb3ProviderInvalid = errors.New("web3 provider invalid")
+
+	// ErrCheckpointSyncURLInvalid is returned when the
+	// checkpoint sync URL is not an http(s) URL.
+	ErrCheckpointSyncURLInvalid = errors.New("checkpoint sync url invalid")
+
+	// ErrPrysmExtraArgInvalid is returned when an extra
+	// argument is empty or the list does not start with a flag.
+	ErrPrysmExtraArgInvalid = errors.New("prysm extra argument invalid")
+)
+
+// PrysmConfig describes how to launch a local prysm beacon node.
+type PrysmConfig struct {
+	Binary     string
+	ConfigFile string
+	DataDir    string
+
+	// NetworkFlags select the chain config preset and the
+	// network prysm joins (e.g. --pyrmont). They are empty
+	// for mainnet.
+	NetworkFlags []string
+
+	// Web3Providers are the execution-layer endpoints prysm
+	// follows the deposit contract on. The first is the primary
+	// provider and the rest are fallbacks.
+	Web3Providers []string
+
+	// CheckpointSyncURL is an optional beacon node API used to
+	// sync from a recent finalized checkpoint.
+	CheckpointSyncURL string
+
+	// ExtraArgs are appended verbatim to the prysm command line.
+	ExtraArgs []string
+}
+
+// Validate returns an error if the PrysmConfig
+// cannot be used to launch prysm.
+func (c *PrysmConfig) Validate() error {
+	if len(c.Binary) == 0 {
+		return ErrPrysmBinaryMissing
+	}
+
+	if len(c.DataDir) == 0 {
+		return ErrPrysmDataDirMissing
+	}
+
+	for i, arg := range c.NetworkFlags {
+		if len(arg) == 0 || (i == 0 && !isFlag(arg)) {
+			return fmt.Errorf("%w: %q", ErrPrysmNetworkFlagInvalid, arg)
+		}
+	}
+
+	if len(c.Web3Providers) == 0 {
+		return ErrWeb3ProviderMissing
+	}
+
+	for _, provider := range c.Web3Providers {
+		if !isURL(provider, "http", "https", "ws", "wss") {
+			return fmt.Errorf("%w: %s", ErrWeb3ProviderInvalid, provider)
+		}
+	}
+
+	if len(c.CheckpointSyncURL) > 0 && !isURL(c.CheckpointSyncURL, "http", "https") {
+		return fmt.Errorf("%w: %s", ErrCheckpointSyncURLInvalid, c.CheckpointSyncURL)
+	}
+
+	// Flag values may be passed as separate arguments
+	// but the list must start with a flag.
+	for i, arg := range c.ExtraArgs {
+		if len(arg) == 0 || (i == 0 && !isFlag(arg)) {
+			return fmt.Errorf("%w: %q", ErrPrysmExtraArgInvalid, arg)
+		}
+	}
+
+	return nil
+}
+
+// Arguments returns the argv (without the binary)
+// used to launch prysm.
+func (c *PrysmConfig) Arguments() []string {
+	args := []string{}
+	if len(c.ConfigFile) > 0 {
+		args = append(args, "--config-file="+c.ConfigFile)
+	}
+
+	args = append(args, "--datadir="+c.DataDir)
+
+	args = append(args, c.NetworkFlags...)
+
+	for i, provider := range c.Web3Providers {
+		if i == 0 {
+			args = append(args, "--http-web3provider="+provider)
+			continue
+		}
+
+		args = append(args, "--fallback-web3provider="+provider)
+	}
+
+	if len(c.CheckpointSyncURL) > 0 {
+		args = append(args, "--checkpoint-sync-url="+c.CheckpointSyncURL)
+	}
+
+	return append(args, c.ExtraArgs...)
+}
+
+func isFlag(arg string) bool {
+	return strings.HasPrefix(arg, "-") && len(strings.TrimLeft(arg, "-")) > 0
+}
+
+func isURL(raw string, schemes ...string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || len(u.Host) == 0 {
+		return false
+	}
+
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return true
+		}
+	}
+
+	return false
+}
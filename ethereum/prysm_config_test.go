@@ -0,0 +1,145 @@
+package ethereum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrysmConfig_Arguments(t *testing.T) {
+	tests := map[string]struct {
+		config *PrysmConfig
+
+		expectedArgs []string
+	}{
+		"mainnet": {
+			config: &PrysmConfig{
+				Binary:        PrysmBinary,
+				ConfigFile:    PrysmConfigFile,
+				DataDir:       "/data",
+				NetworkFlags:  MainnetNetworkConfig.PrysmArguments(),
+				Web3Providers: []string{"http://localhost:8545"},
+			},
+			expectedArgs: []string{
+				"--config-file=/app/ethereum/prysm-config.yaml",
+				"--datadir=/data",
+				"--http-web3provider=http://localhost:8545",
+			},
+		},
+		"testnet": {
+			config: &PrysmConfig{
+				Binary:        PrysmBinary,
+				ConfigFile:    PrysmConfigFile,
+				DataDir:       "/data",
+				NetworkFlags:  TestnetNetworkConfig.PrysmArguments(),
+				Web3Providers: []string{"http://localhost:8545"},
+			},
+			expectedArgs: []string{
+				"--config-file=/app/ethereum/prysm-config.yaml",
+				"--datadir=/data",
+				"--pyrmont",
+				"--http-web3provider=http://localhost:8545",
+			},
+		},
+		"fallbacks, checkpoint sync and extra args": {
+			config: &PrysmConfig{
+				Binary:       PrysmBinary,
+				DataDir:      "/data",
+				NetworkFlags: TestnetNetworkConfig.PrysmArguments(),
+				Web3Providers: []string{
+					"https://eth1.example.com/key",
+					"wss://eth1-backup.example.com",
+				},
+				CheckpointSyncURL: "https://beacon.example.com",
+				ExtraArgs:         []string{"--p2p-max-peers=50", "--verbosity", "debug"},
+			},
+			expectedArgs: []string{
+				"--datadir=/data",
+				"--pyrmont",
+				"--http-web3provider=https://eth1.example.com/key",
+				"--fallback-web3provider=wss://eth1-backup.example.com",
+				"--checkpoint-sync-url=https://beacon.example.com",
+				"--p2p-max-peers=50",
+				"--verbosity",
+				"debug",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedArgs, test.config.Arguments())
+		})
+	}
+}
+
+func TestPrysmConfig_Validate(t *testing.T) {
+	valid := func() *PrysmConfig {
+		return &PrysmConfig{
+			Binary:        PrysmBinary,
+			DataDir:       "/data",
+			Web3Providers: []string{"http://localhost:8545"},
+		}
+	}
+
+	tests := map[string]struct {
+		modify func(*PrysmConfig)
+
+		expectedError error
+	}{
+		"valid": {
+			modify: func(*PrysmConfig) {},
+		},
+		"missing binary": {
+			modify:        func(c *PrysmConfig) { c.Binary = "" },
+			expectedError: ErrPrysmBinaryMissing,
+		},
+		"missing datadir": {
+			modify:        func(c *PrysmConfig) { c.DataDir = "" },
+			expectedError: ErrPrysmDataDirMissing,
+		},
+		"invalid network flag": {
+			modify:        func(c *PrysmConfig) { c.NetworkFlags = []string{"pyrmont"} },
+			expectedError: ErrPrysmNetworkFlagInvalid,
+		},
+		"missing web3 provider": {
+			modify:        func(c *PrysmConfig) { c.Web3Providers = nil },
+			expectedError: ErrWeb3ProviderMissing,
+		},
+		"invalid web3 provider": {
+			modify:        func(c *PrysmConfig) { c.Web3Providers = []string{"localhost:8545"} },
+			expectedError: ErrWeb3ProviderInvalid,
+		},
+		"invalid checkpoint sync url": {
+			modify:        func(c *PrysmConfig) { c.CheckpointSyncURL = "ws://beacon" },
+			expectedError: ErrCheckpointSyncURLInvalid,
+		},
+		"extra args with values": {
+			modify: func(c *PrysmConfig) { c.ExtraArgs = []string{"--verbosity", "debug"} },
+		},
+		"extra args without flag": {
+			modify:        func(c *PrysmConfig) { c.ExtraArgs = []string{"debug"} },
+			expectedError: ErrPrysmExtraArgInvalid,
+		},
+		"empty extra arg": {
+			modify:        func(c *PrysmConfig) { c.ExtraArgs = []string{"--verbosity", ""} },
+			expectedError: ErrPrysmExtraArgInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := valid()
+			test.modify(config)
+
+			err := config.Validate()
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, test.expectedError))
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package ethereum
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// prysmPeerPrefix is the logger prefix of the
+	// prysm p2p service.
+	prysmPeerPrefix = "p2p"
+
+	// prysmDatabasePrefix is the logger prefix of the
+	// prysm database service.
+	prysmDatabasePrefix = "db"
+)
+
+var (
+	// prysmBracketLog matches lines written by prysm's
+	// prefixed formatter when attached to a terminal:
+	// [2020-12-01 13:05:29]  INFO p2p: Peer summary activePeers=30
+	prysmBracketLog = regexp.MustCompile(`^\[([^\]]+)\]\s+([A-Z]+)\s+(?:([\w-]+):\s+)?(.*)$`)
+
+	// prysmInitialSyncProgress matches the slot progress
+	// printed by initial sync, e.g. "... 1024/2048 - estimated".
+	prysmInitialSyncProgress = regexp.MustCompile(`\s(\d+)/(\d+) - estimated`)
+
+	// prysmChainStartMessages are the messages prysm logs once
+	// the beacon state is initialized: when resuming from its
+	// database, when starting initial sync and when started
+	// within an epoch of genesis. Sync progress also implies
+	// that the chain has started.
+	prysmChainStartMessages = map[string]bool{
+		"Blockchain data already exists in DB, initializing...": true,
+		"Starting initial chain sync...":                        true,
+		"Chain started within the last epoch - not syncing":     true,
+	}
+)
+
+// PrysmLogEntry is a single parsed prysm log line.
+type PrysmLogEntry struct {
+	Time    string
+	Level   string
+	Prefix  string
+	Message string
+	Fields  map[string]string
+}
+
+// String renders the entry in logfmt so it
+// can be re-emitted as a structured line.
+func (e *PrysmLogEntry) String() string {
+	var b strings.Builder
+	if len(e.Level) > 0 {
+		fmt.Fprintf(&b, "level=%s ", e.Level)
+	}
+	if len(e.Prefix) > 0 {
+		fmt.Fprintf(&b, "prefix=%s ", e.Prefix)
+	}
+	fmt.Fprintf(&b, "msg=%q", e.Message)
+
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%s", key, quoteLogValue(e.Fields[key]))
+	}
+
+	return b.String()
+}
+
+// parsePrysmLog parses a line written by prysm's logrus
+// formatter. Lines that cannot be parsed are returned as
+// the message of an entry without a level.
+func parsePrysmLog(line string) *PrysmLogEntry {
+	line = strings.TrimSpace(line)
+	if matches := prysmBracketLog.FindStringSubmatch(line); matches != nil {
+		message, fields := splitTrailingFields(matches[4])
+		return &PrysmLogEntry{
+			Time:    matches[1],
+			Level:   normalizeLevel(matches[2]),
+			Prefix:  matches[3],
+			Message: message,
+			Fields:  fields,
+		}
+	}
+
+	pairs, ok := parseLogfmt(line)
+	if !ok {
+		return &PrysmLogEntry{Message: line, Fields: map[string]string{}}
+	}
+
+	entry := &PrysmLogEntry{Fields: map[string]string{}}
+	for _, pair := range pairs {
+		switch pair[0] {
+		case "time":
+			entry.Time = pair[1]
+		case "level":
+			entry.Level = normalizeLevel(pair[1])
+		case "msg":
+			entry.Message = pair[1]
+		case "prefix":
+			entry.Prefix = pair[1]
+		default:
+			entry.Fields[pair[0]] = pair[1]
+		}
+	}
+	if len(entry.Level) == 0 {
+		return &PrysmLogEntry{Message: line, Fields: map[string]string{}}
+	}
+
+	return entry
+}
+
+// parseLogfmt splits a line into key=value pairs, unquoting
+// quoted values. It returns false if any token is not a pair.
+func parseLogfmt(line string) ([][2]string, bool) {
+	pairs := [][2]string{}
+	for len(line) > 0 {
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 || strings.ContainsAny(line[:eq], " \t\"") {
+			return nil, false
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := closingQuote(line)
+			if end < 0 {
+				return nil, false
+			}
+			unquoted, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+			line = line[end+1:]
+		} else {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				end = len(line)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+
+		pairs = append(pairs, [2]string{key, value})
+		line = strings.TrimLeft(line, " \t")
+	}
+
+	return pairs, true
+}
+
+// closingQuote returns the index of the quote closing
+// the quoted string at the start of s.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// splitTrailingFields separates trailing key=value
+// tokens from the message they follow.
+func splitTrailingFields(s string) (string, map[string]string) {
+	fields := map[string]string{}
+	tokens := strings.Fields(s)
+	end := len(tokens)
+	for end > 0 {
+		eq := strings.IndexByte(tokens[end-1], '=')
+		if eq <= 0 {
+			break
+		}
+		fields[tokens[end-1][:eq]] = tokens[end-1][eq+1:]
+		end--
+	}
+
+	return strings.Join(tokens[:end], " "), fields
+}
+
+func normalizeLevel(level string) string {
+	switch level = strings.ToLower(level); level {
+	case "warn":
+		return "warning"
+	case "erro":
+		return "error"
+	case "fata":
+		return "fatal"
+	default:
+		return level
+	}
+}
+
+func quoteLogValue(value string) string {
+	if len(value) == 0 || strings.ContainsAny(value, " \t\"=") {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// observe updates the status with any key
+// event recognized in the entry.
+func (s *PrysmStatus) observe(entry *PrysmLogEntry) {
+	switch {
+	case entry.Prefix == prysmPeerPrefix && entry.Message == "Peer summary":
+		if peers, err := strconv.Atoi(entry.Fields["activePeers"]); err == nil {
+			s.Peers = peers
+		}
+	case entry.Message == "Synced new block":
+		if slot, err := strconv.ParseInt(entry.Fields["slot"], 10, 64); err == nil {
+			s.SyncSlot = slot
+			s.chainStarted()
+		}
+	case strings.HasPrefix(entry.Message, "Processing block batch"):
+		if matches := prysmInitialSyncProgress.FindStringSubmatch(entry.Message); matches != nil {
+			if slot, err := strconv.ParseInt(matches[1], 10, 64); err == nil {
+				s.SyncSlot = slot
+				s.chainStarted()
+			}
+		}
+	case prysmChainStartMessages[entry.Message]:
+		s.chainStarted()
+	case isFatalLevel(entry.Level) && isDatabaseEntry(entry):
+		s.DatabaseError = entry.Message
+	}
+}
+
+// chainStarted records that the chain has started. A process
+// that gets this far has opened its database, so the database
+// error of a previous process is cleared.
+func (s *PrysmStatus) chainStarted() {
+	s.ChainStarted = true
+	s.DatabaseError = ""
+}
+
+func isFatalLevel(level string) bool {
+	return level == "fatal" || level == "panic"
+}
+
+func isDatabaseEntry(entry *PrysmLogEntry) bool {
+	if entry.Prefix == prysmDatabasePrefix {
+		return true
+	}
+
+	message := strings.ToLower(entry.Message + " " + entry.Fields["error"])
+	return strings.Contains(message, "database") || strings.Contains(message, " db")
+}
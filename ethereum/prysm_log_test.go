@@ -0,0 +1,145 @@
+package ethereum
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrysmLog(t *testing.T) {
+	tests := map[string]struct {
+		line string
+
+		expected *PrysmLogEntry
+	}{
+		"logfmt": {
+			line: `time="2020-12-01 13:05:29" level=info msg="Peer summary" activePeers=30 inbound=0 outbound=30 prefix=p2p`,
+			expected: &PrysmLogEntry{
+				Time:    "2020-12-01 13:05:29",
+				Level:   "info",
+				Prefix:  "p2p",
+				Message: "Peer summary",
+				Fields: map[string]string{
+					"activePeers": "30",
+					"inbound":     "0",
+					"outbound":    "30",
+				},
+			},
+		},
+		"logfmt with escaped quotes": {
+			line: `time="2020-12-01 13:05:29" level=error msg="Could not \"connect\"" error="dial tcp: i/o timeout" prefix=powchain`,
+			expected: &PrysmLogEntry{
+				Time:    "2020-12-01 13:05:29",
+				Level:   "error",
+				Prefix:  "powchain",
+				Message: `Could not "connect"`,
+				Fields: map[string]string{
+					"error": "dial tcp: i/o timeout",
+				},
+			},
+		},
+		"bracket": {
+			line: `[2020-12-01 13:05:29]  WARN initial-sync: Processing block batch of size 64 starting from  0x3a8d8c9a... 1024/2048 - estimated time remaining 8m20s blocksPerSecond=4.8 peers=43`,
+			expected: &PrysmLogEntry{
+				Time:    "2020-12-01 13:05:29",
+				Level:   "warning",
+				Prefix:  "initial-sync",
+				Message: "Processing block batch of size 64 starting from 0x3a8d8c9a... 1024/2048 - estimated time remaining 8m20s",
+				Fields: map[string]string{
+					"blocksPerSecond": "4.8",
+					"peers":           "43",
+				},
+			},
+		},
+		"unstructured": {
+			line: "panic: runtime error: index out of range",
+			expected: &PrysmLogEntry{
+				Message: "panic: runtime error: index out of range",
+				Fields:  map[string]string{},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parsePrysmLog(test.line))
+		})
+	}
+}
+
+func TestPrysmLogEntry_String(t *testing.T) {
+	entry := &PrysmLogEntry{
+		Level:   "info",
+		Prefix:  "p2p",
+		Message: "Peer summary",
+		Fields: map[string]string{
+			"outbound":    "30",
+			"activePeers": "30",
+			"error":       "i/o timeout",
+		},
+	}
+
+	assert.Equal(
+		t,
+		`level=info prefix=p2p msg="Peer summary" activePeers=30 error="i/o timeout" outbound=30`,
+		entry.String(),
+	)
+}
+
+func TestPrysmStatus_Observe(t *testing.T) {
+	status := &PrysmStatus{}
+	status.observe(parsePrysmLog(
+		`time="2020-12-01 13:05:28" level=info msg="Waiting for chain start before starting sync" prefix=initial-sync`,
+	))
+	assert.False(t, status.ChainStarted)
+
+	lines := []string{
+		`time="2020-12-01 13:05:29" level=info msg="Chain started within the last epoch - not syncing" prefix=initial-sync`,
+		`time="2020-12-01 13:05:30" level=info msg="Peer summary" activePeers=12 inbound=2 outbound=10 prefix=p2p`,
+		`time="2020-12-01 13:05:31" level=info msg="Processing block batch of size 64 starting from  0x3a8d8c9a... 1024/2048 - estimated time remaining 8m20s" blocksPerSecond=4.8 peers=43 prefix=initial-sync`,
+		`time="2020-12-01 13:05:32" level=info msg="Synced new block" block=0x8a3b... epoch=64 slot=2049 prefix=blockchain`,
+	}
+
+	for _, line := range lines {
+		status.observe(parsePrysmLog(line))
+	}
+	assert.Equal(t, &PrysmStatus{
+		Peers:        12,
+		SyncSlot:     2049,
+		ChainStarted: true,
+	}, status)
+
+	status.observe(parsePrysmLog(
+		`time="2020-12-01 13:05:33" level=fatal msg="Could not open database" error=timeout prefix=node`,
+	))
+	assert.Equal(t, "Could not open database", status.DatabaseError)
+
+	restarted := &PrysmStatus{DatabaseError: status.DatabaseError}
+	restarted.observe(parsePrysmLog(
+		`time="2020-12-01 13:06:00" level=info msg="Peer summary" activePeers=3 prefix=p2p`,
+	))
+	assert.Equal(t, "Could not open database", restarted.DatabaseError)
+
+	restarted.observe(parsePrysmLog(
+		`time="2020-12-01 13:06:01" level=info msg="Blockchain data already exists in DB, initializing..." prefix=blockchain`,
+	))
+	assert.True(t, restarted.ChainStarted)
+	assert.Empty(t, restarted.DatabaseError)
+}
+
+func TestLogPipe(t *testing.T) {
+	pipe := ioutil.NopCloser(strings.NewReader(
+		"time=\"2020-12-01 13:05:30\" level=info msg=\"Peer summary\" activePeers=7 prefix=p2p\n\nno newline",
+	))
+
+	entries := []*PrysmLogEntry{}
+	assert.NoError(t, logPipe(pipe, prysmLogger, func(entry *PrysmLogEntry) {
+		entries = append(entries, entry)
+	}))
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Peer summary", entries[0].Message)
+	assert.Equal(t, "no newline", entries[1].Message)
+}
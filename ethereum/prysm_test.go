@@ -0,0 +1,105 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSupervisor(script string) *PrysmSupervisor {
+	s := NewPrysmSupervisor("/bin/sh", []string{"-c", script})
+	s.gracePeriod = 200 * time.Millisecond
+	s.minBackoff = time.Millisecond
+	s.maxBackoff = 5 * time.Millisecond
+	s.restartWindow = time.Minute
+	s.maxRestarts = 3
+
+	return s
+}
+
+func TestPrysmSupervisor_RestartLimit(t *testing.T) {
+	s := newTestSupervisor("echo started; exit 3")
+
+	err := s.Run(context.Background())
+	assert.True(t, errors.Is(err, ErrPrysmRestartLimit))
+
+	status := s.Status()
+	assert.False(t, status.Running)
+	assert.Equal(t, 3, status.Restarts)
+	assert.Equal(t, "exit status 3", status.LastExitStatus)
+	assert.NotZero(t, status.LastExitTime)
+}
+
+func TestPrysmSupervisor_DatabaseErrorAcrossRestarts(t *testing.T) {
+	s := newTestSupervisor(`echo 'level=fatal msg="Could not open database" prefix=db'; exit 1`)
+
+	err := s.Run(context.Background())
+	assert.True(t, errors.Is(err, ErrPrysmRestartLimit))
+
+	status := s.Status()
+	assert.False(t, status.Running)
+	assert.False(t, status.ChainStarted)
+	assert.Equal(t, "Could not open database", status.DatabaseError)
+}
+
+func TestPrysmSupervisor_GracefulShutdown(t *testing.T) {
+	s := newTestSupervisor("trap 'exit 0' INT; while :; do sleep 0.01; done")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return s.Status().Running
+	}, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("supervisor did not stop")
+	}
+
+	status := s.Status()
+	assert.False(t, status.Running)
+	assert.Equal(t, 0, status.Restarts)
+	assert.Empty(t, status.LastExitStatus)
+}
+
+func TestPrysmSupervisor_KillAfterGracePeriod(t *testing.T) {
+	s := newTestSupervisor("trap '' INT; while :; do :; done")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return s.Status().Running
+	}, time.Second, time.Millisecond)
+	start := time.Now()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+		assert.True(t, time.Since(start) >= s.gracePeriod)
+	case <-time.After(2 * time.Second):
+		t.Fatal("supervisor did not kill prysm")
+	}
+}
+
+func TestPrysmSupervisor_StartFailure(t *testing.T) {
+	s := NewPrysmSupervisor("/nonexistent/beacon-chain", nil)
+
+	err := s.Run(context.Background())
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrPrysmRestartLimit))
+}
@@ -0,0 +1,147 @@
+package ethereum
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	// ForkVersionLength is the length in
+	// bytes of a fork version.
+	ForkVersionLength = 4
+
+	// SignatureLength is the length in bytes of
+	// a compressed BLS12-381 signature.
+	SignatureLength = 96
+)
+
+// DomainType identifies the kind of
+// object a signature is for.
+type DomainType [4]byte
+
+var (
+	// DomainDeposit is the domain
+	// type of deposits.
+	DomainDeposit = DomainType{0x03, 0x00, 0x00, 0x00}
+
+	// DomainVoluntaryExit is the domain
+	// type of voluntary exits.
+	DomainVoluntaryExit = DomainType{0x04, 0x00, 0x00, 0x00}
+)
+
+// ComputeDomain returns the signature domain of domainType
+// for forkVersion on the chain with genesisValidatorsRoot
+// (compute_domain in the spec).
+func ComputeDomain(
+	domainType DomainType,
+	forkVersion [ForkVersionLength]byte,
+	genesisValidatorsRoot [rootLength]byte,
+) [rootLength]byte {
+	forkDataRoot := ForkDataRoot(forkVersion, genesisValidatorsRoot)
+
+	var domain [rootLength]byte
+	copy(domain[:], domainType[:])
+	copy(domain[len(domainType):], forkDataRoot[:rootLength-len(domainType)])
+
+	return domain
+}
+
+// ComputeForkDigest returns the digest identifying
+// forkVersion on the chain with genesisValidatorsRoot
+// (compute_fork_digest in the spec).
+func ComputeForkDigest(
+	forkVersion [ForkVersionLength]byte,
+	genesisValidatorsRoot [rootLength]byte,
+) [ForkVersionLength]byte {
+	forkDataRoot := ForkDataRoot(forkVersion, genesisValidatorsRoot)
+
+	var digest [ForkVersionLength]byte
+	copy(digest[:], forkDataRoot[:])
+	return digest
+}
+
+// ComputeSigningRoot returns the root signed for an object
+// with hash tree root objectRoot in domain
+// (compute_signing_root in the spec).
+func ComputeSigningRoot(objectRoot [rootLength]byte, domain [rootLength]byte) [rootLength]byte {
+	return hashChunks(objectRoot, domain)
+}
+
+// DepositDomain returns the signature domain of deposits,
+// which do not depend on the genesis validators root so
+// that they can be signed before genesis.
+func DepositDomain(genesisForkVersion [ForkVersionLength]byte) [rootLength]byte {
+	return ComputeDomain(DomainDeposit, genesisForkVersion, [rootLength]byte{})
+}
+
+// DepositMessageRoot returns the hash tree root of the
+// DepositMessage signed by the validator, which is the
+// deposit data without its signature. amount is in Gwei.
+func DepositMessageRoot(pubkey []byte, withdrawalCredentials []byte, amount uint64) [rootLength]byte {
+	return hashChunks(
+		hashChunks(bytesRoot(pubkey), bytesRoot(withdrawalCredentials)),
+		hashChunks(uint64Chunk(amount), [rootLength]byte{}),
+	)
+}
+
+// DepositDataRoot returns the hash tree root of the deposit
+// data, which the deposit contract checks its input against.
+func DepositDataRoot(data *pb.Deposit_Data) [rootLength]byte {
+	return hashChunks(
+		hashChunks(bytesRoot(data.PublicKey), bytesRoot(data.WithdrawalCredentials)),
+		hashChunks(uint64Chunk(data.Amount), bytesRoot(data.Signature)),
+	)
+}
+
+// ForkDataRoot returns the hash tree root
+// of the ForkData container.
+func ForkDataRoot(
+	currentVersion [ForkVersionLength]byte,
+	genesisValidatorsRoot [rootLength]byte,
+) [rootLength]byte {
+	var versionChunk [rootLength]byte
+	copy(versionChunk[:], currentVersion[:])
+	return hashChunks(versionChunk, genesisValidatorsRoot)
+}
+
+// VoluntaryExitRoot returns the hash tree root of exit.
+func VoluntaryExitRoot(exit *pb.VoluntaryExit) [rootLength]byte {
+	return hashChunks(uint64Chunk(exit.Epoch), uint64Chunk(exit.ValidatorIndex))
+}
+
+// uint64Chunk returns the SSZ chunk of v.
+func uint64Chunk(v uint64) [rootLength]byte {
+	var chunk [rootLength]byte
+	binary.LittleEndian.PutUint64(chunk[:], v)
+	return chunk
+}
+
+// bytesRoot returns the hash tree root of a fixed-size
+// byte vector of at most four chunks, such as a public
+// key, withdrawal credentials or a signature.
+func bytesRoot(b []byte) [rootLength]byte {
+	var chunks [4][rootLength]byte
+	for i := range chunks {
+		if i*rootLength < len(b) {
+			copy(chunks[i][:], b[i*rootLength:])
+		}
+	}
+
+	if len(b) <= rootLength {
+		return chunks[0]
+	}
+	if len(b) <= 2*rootLength {
+		return hashChunks(chunks[0], chunks[1])
+	}
+
+	return hashChunks(hashChunks(chunks[0], chunks[1]), hashChunks(chunks[2], chunks[3]))
+}
+
+// hashChunks returns the merkle root of two chunks,
+// which is the hash tree root of a container with
+// two fields of at most 32 bytes.
+func hashChunks(a [rootLength]byte, b [rootLength]byte) [rootLength]byte {
+	return sha256.Sum256(append(a[:], b[:]...))
+}
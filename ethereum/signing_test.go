@@ -0,0 +1,127 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDomain(t *testing.T) {
+	// DOMAIN_DEPOSIT on mainnet, which is computed
+	// with the genesis fork version and a zero
+	// genesis validators root.
+	domain := ComputeDomain(
+		DomainType{0x03, 0x00, 0x00, 0x00},
+		[ForkVersionLength]byte{},
+		[rootLength]byte{},
+	)
+	assert.Equal(
+		t,
+		"03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		hex.EncodeToString(domain[:]),
+	)
+}
+
+func TestComputeForkDigest(t *testing.T) {
+	var genesisValidatorsRoot [rootLength]byte
+	copy(genesisValidatorsRoot[:], mainnetGenesisValidatorsRoot)
+
+	// The fork digest of the mainnet genesis
+	// fork, as advertised in its ENRs.
+	digest := ComputeForkDigest(MainnetForkSchedule.GenesisVersion(), genesisValidatorsRoot)
+	assert.Equal(t, "b5303f2a", hex.EncodeToString(digest[:]))
+
+	// Domains embed the fork digest.
+	domain := ComputeDomain(DomainVoluntaryExit, MainnetForkSchedule.GenesisVersion(), genesisValidatorsRoot)
+	assert.Equal(
+		t,
+		"04000000b5303f2ad2010d699a76c8e62350947421a3e4a979779642cfdb0f66",
+		hex.EncodeToString(domain[:]),
+	)
+
+	// The Pyrmont genesis fork.
+	pyrmontGenesisValidatorsRoot, _ := hex.DecodeString(TestnetNetworkConfig.GenesisValidatorsRoot)
+	copy(genesisValidatorsRoot[:], pyrmontGenesisValidatorsRoot)
+	digest = ComputeForkDigest(TestnetForkSchedule.GenesisVersion(), genesisValidatorsRoot)
+	assert.Equal(t, "4b70599d", hex.EncodeToString(digest[:]))
+}
+
+func TestComputeSigningRoot(t *testing.T) {
+	// compute_signing_root(VoluntaryExit(epoch=1, validator_index=2),
+	// DOMAIN_DEPOSIT on mainnet), per the consensus specs.
+	objectRoot := VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2})
+	assert.Equal(
+		t,
+		"ff55c97976a840b4ced964ed49e3794594ba3f675238b5fd25d282b60f70a194",
+		hex.EncodeToString(objectRoot[:]),
+	)
+
+	root := ComputeSigningRoot(objectRoot, DepositDomain([ForkVersionLength]byte{}))
+	assert.Equal(
+		t,
+		"0aabd290ab3baf34cae99dcb6ff95a65e1a22df6adc97343710676ba87459676",
+		hex.EncodeToString(root[:]),
+	)
+}
+
+func TestVoluntaryExitRoot(t *testing.T) {
+	// The root of two zero chunks.
+	root := VoluntaryExitRoot(&pb.VoluntaryExit{})
+	assert.Equal(
+		t,
+		"f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b",
+		hex.EncodeToString(root[:]),
+	)
+
+	exit := &pb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}
+	root = VoluntaryExitRoot(exit)
+	expected, err := exit.HashTreeRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, root)
+	assert.NotEqual(t, VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: 2, ValidatorIndex: 1}), root)
+}
+
+func TestDepositMessageRoot(t *testing.T) {
+	pubkey, _ := hex.DecodeString(
+		"97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb",
+	)
+	creds := make([]byte, rootLength)
+	creds[rootLength-1] = 0x01
+
+	// hash_tree_root of the DepositMessage container.
+	root := DepositMessageRoot(pubkey, creds, 32000000000)
+	assert.Equal(
+		t,
+		"041aa3280264f0cd5cb6e59af6a4acfe83f98524c047aca6f2c3cce2820ced3d",
+		hex.EncodeToString(root[:]),
+	)
+}
+
+func TestDepositDataRoot(t *testing.T) {
+	tests := map[string]*pb.Deposit_Data{
+		"zero": {
+			PublicKey:             make([]byte, PubkeyLength),
+			WithdrawalCredentials: make([]byte, rootLength),
+			Signature:             make([]byte, SignatureLength),
+		},
+		"populated": {
+			PublicKey:             testPubkey(7),
+			WithdrawalCredentials: testCredentials(2),
+			Amount:                32000000000,
+			Signature:             bytes.Repeat([]byte{0xa1}, SignatureLength),
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The deposit data generated by
+			// prysm serves as reference.
+			expected, err := data.HashTreeRoot()
+			assert.NoError(t, err)
+			assert.Equal(t, expected, DepositDataRoot(data))
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package ethereum
 
 import (
-	"fmt"
-
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
@@ -53,21 +51,39 @@ const (
 	// Coinbase.
 	CoinbaseOpType = "COINBASE"
 
-	// MainnetPrysmArguments are the arguments to start a mainnet Prysm instance.
-	MainnetPrysmArguments = `--config-file=/app/ethereum/prysm-config.yaml --datadir=/data`
+	// VoluntaryExitOpType is used to describe
+	// the voluntary exit of a validator.
+	VoluntaryExitOpType = "VOLUNTARY_EXIT"
+
+	// DepositOpType is used to describe a deposit
+	// to the deposit contract funding a validator.
+	DepositOpType = "DEPOSIT"
+
+	// AttestationOpType is used to describe an
+	// attestation by a committee of validators.
+	AttestationOpType = "ATTESTATION"
+
+	// ProposerSlashingOpType is used to describe the slashing
+	// of a validator proposing two blocks for a slot.
+	ProposerSlashingOpType = "PROPOSER_SLASHING"
+
+	// AttesterSlashingOpType is used to describe the slashing
+	// of a validator making conflicting attestations.
+	AttesterSlashingOpType = "ATTESTER_SLASHING"
 )
 
 var (
-	// TestnetPrysmArguments are the arguments to start a ropsten Prysm instance.
-	TestnetPrysmArguments = fmt.Sprintf("%s --pyrmont", MainnetPrysmArguments)
-
 	// MainnetGenesisBlockIdentifier is the *types.BlockIdentifier
 	// of the mainnet genesis block.
-	MainnetGenesisBlockIdentifier = &types.BlockIdentifier{}
+	MainnetGenesisBlockIdentifier = &types.BlockIdentifier{
+		Index: 0,
+		Hash:  "4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360",
+	}
 
 	// TestnetGenesisBlockIdentifier is the *types.BlockIdentifier
-	// of the testnet genesis block.
-	TestnetGenesisBlockIdentifier = &types.BlockIdentifier{}
+	// of the testnet genesis block. It is not pinned and is
+	// instead read from the beacon node.
+	TestnetGenesisBlockIdentifier *types.BlockIdentifier
 
 	// Currency is the *types.Currency for all
 	// Ethereum networks.
@@ -81,6 +97,11 @@ var (
 		InputOpType,
 		OutputOpType,
 		CoinbaseOpType,
+		VoluntaryExitOpType,
+		DepositOpType,
+		AttestationOpType,
+		ProposerSlashingOpType,
+		AttesterSlashingOpType,
 	}
 
 	// OperationStatuses are all supported operation statuses.
@@ -96,5 +117,14 @@ var (
 	}
 
 	// CallMethods are all supported call methods.
-	CallMethods = []string{}
+	CallMethods = []string{
+		ValidatorStatusMethod,
+		CommitteesMethod,
+		ProposerDutiesMethod,
+		ValidatorDutiesMethod,
+		FinalityCheckpointsMethod,
+		ChainConfigMethod,
+		ParticipationMethod,
+		PendingDepositsMethod,
+	}
 )
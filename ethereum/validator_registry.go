@@ -0,0 +1,452 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"rosetta-ethereum-2.0/timeutils"
+
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// PubkeyLength is the length in bytes of
+	// a compressed BLS12-381 public key.
+	PubkeyLength = 48
+
+	// DefaultValidatorRegistrySize is the default maximum
+	// number of validators kept in a ValidatorRegistry.
+	// Each entry takes roughly 250 bytes.
+	DefaultValidatorRegistrySize = 1 << 20
+
+	// validatorsPageSize is the number of validators
+	// requested per ListValidators call. It must not
+	// exceed prysm's --rpc-max-page-size (250 by default).
+	validatorsPageSize = 250
+)
+
+var (
+	// ErrValidatorNotFound is returned when no validator
+	// matches an index or public key.
+	ErrValidatorNotFound = errors.New("validator not found")
+
+	// ErrPubkeyInvalid is returned when a public
+	// key is not 48 bytes long.
+	ErrPubkeyInvalid = errors.New("public key invalid")
+
+	// ErrValidatorRegistryGap is returned when the beacon node
+	// skips validator indices while listing the registry.
+	ErrValidatorRegistryGap = errors.New("validator registry gap")
+
+	// ErrValidatorRegistryIncomplete is returned when a lookup
+	// needs the whole registry but it holds more validators
+	// than the ValidatorRegistry can cache.
+	ErrValidatorRegistryIncomplete = errors.New("validator registry incomplete")
+)
+
+// validatorPubkey is a fixed-size public key,
+// so it can be used as a map key without copies.
+type validatorPubkey [PubkeyLength]byte
+
+// withdrawalCredentials are fixed-size withdrawal
+// credentials, used as a map key.
+type withdrawalCredentials [rootLength]byte
+
+// ValidatorRegistry caches the mapping between validator indices
+// and public keys.
+//
+// The beacon state registry is append-only, so the cache is
+// loaded once and then extended with the validators added by
+// newly processed deposits. Refreshes happen at most once per
+// epoch and run in the background, started by Warm or by the
+// first lookup of the epoch. Pages are fetched without holding
+// the cache lock, so lookups are never blocked by a refresh.
+// At most maxValidators entries are kept. Lookups the cache
+// cannot serve, including those made while it is still
+// loading, are forwarded to the beacon node.
+//
+// Validators are also indexed by withdrawal credentials. Since
+// Capella, BLS credentials can be changed to an execution address,
+// so each refresh re-reads the validators still holding BLS
+// credentials and re-indexes those whose credentials changed.
+type ValidatorRegistry struct {
+	client        pb.BeaconChainClient
+	network       *NetworkConfig
+	maxValidators int
+
+	// now is overridden in tests.
+	now func() time.Time
+
+	// refreshLock serializes refreshes, which page
+	// through the beacon node without holding lock.
+	refreshLock sync.Mutex
+
+	// background tracks background refreshes
+	// so that tests can wait for them.
+	background sync.WaitGroup
+
+	lock        sync.RWMutex
+	refreshing  bool
+	refreshed   bool
+	complete    bool
+	epoch       uint64
+	pubkeys     []validatorPubkey
+	withdrawals []withdrawalCredentials
+	indices     map[validatorPubkey]uint64
+	credentials map[withdrawalCredentials][]uint64
+}
+
+// NewValidatorRegistry creates a new ValidatorRegistry
+// holding at most maxValidators validators.
+func NewValidatorRegistry(
+	client pb.BeaconChainClient,
+	network *NetworkConfig,
+	maxValidators int,
+) *ValidatorRegistry {
+	return &ValidatorRegistry{
+		client:        client,
+		network:       network,
+		maxValidators: maxValidators,
+		now:           timeutils.Now,
+		indices:       map[validatorPubkey]uint64{},
+		credentials:   map[withdrawalCredentials][]uint64{},
+	}
+}
+
+// Len returns the number of cached validators.
+func (r *ValidatorRegistry) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return len(r.pubkeys)
+}
+
+// Warm starts loading the registry in the background
+// if it was not refreshed in the current epoch.
+func (r *ValidatorRegistry) Warm() {
+	r.refreshIfStale()
+}
+
+// Pubkey returns the public key of the
+// validator at index.
+func (r *ValidatorRegistry) Pubkey(ctx context.Context, index uint64) ([]byte, error) {
+	r.refreshIfStale()
+
+	r.lock.RLock()
+	if index < uint64(len(r.pubkeys)) {
+		pubkey := r.pubkeys[index]
+		r.lock.RUnlock()
+		return pubkey[:], nil
+	}
+	r.lock.RUnlock()
+
+	validator, err := r.client.GetValidator(ctx, &pb.GetValidatorRequest{
+		QueryFilter: &pb.GetValidatorRequest_Index{Index: index},
+	})
+	if err != nil {
+		return nil, validatorErr(err, strconv.FormatUint(index, 10))
+	}
+
+	return validator.PublicKey, nil
+}
+
+// Index returns the index of the validator
+// with the public key pubkey.
+func (r *ValidatorRegistry) Index(ctx context.Context, pubkey []byte) (uint64, error) {
+	if len(pubkey) != PubkeyLength {
+		return 0, fmt.Errorf("%w: %d bytes", ErrPubkeyInvalid, len(pubkey))
+	}
+
+	r.refreshIfStale()
+
+	var key validatorPubkey
+	copy(key[:], pubkey)
+
+	r.lock.RLock()
+	index, ok := r.indices[key]
+	r.lock.RUnlock()
+	if ok {
+		return index, nil
+	}
+
+	res, err := r.client.ListValidators(ctx, &pb.ListValidatorsRequest{
+		PublicKeys: [][]byte{pubkey},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: could not list validators", err)
+	}
+	if len(res.ValidatorList) < 1 {
+		return 0, fmt.Errorf("%w: %s", ErrValidatorNotFound, hex.EncodeToString(pubkey))
+	}
+
+	return res.ValidatorList[0].Index, nil
+}
+
+// WithdrawalIndices returns the indices of all validators
+// with withdrawal credentials creds, in increasing order.
+// Validators deposited or whose credentials changed
+// since the last refresh are not included. As it needs the whole registry, it waits for
+// a stale registry to be refreshed.
+func (r *ValidatorRegistry) WithdrawalIndices(ctx context.Context, creds []byte) ([]uint64, error) {
+	if len(creds) != rootLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrWithdrawalCredentialsInvalid, len(creds))
+	}
+
+	if err := r.Refresh(ctx, r.network.Epoch(r.now())); err != nil {
+		return nil, err
+	}
+
+	var key withdrawalCredentials
+	copy(key[:], creds)
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.complete {
+		return nil, fmt.Errorf("%w: more than %d validators", ErrValidatorRegistryIncomplete, r.maxValidators)
+	}
+
+	return append([]uint64{}, r.credentials[key]...), nil
+}
+
+// refreshIfStale starts refreshing the registry in the
+// background if it was not refreshed in the current epoch
+// and no refresh is running.
+func (r *ValidatorRegistry) refreshIfStale() {
+	epoch := r.network.Epoch(r.now())
+
+	r.lock.Lock()
+	stale := (!r.refreshed || r.epoch < epoch) && !r.refreshing
+	if stale {
+		r.refreshing = true
+	}
+	r.lock.Unlock()
+	if !stale {
+		return
+	}
+
+	r.background.Add(1)
+	go func() {
+		defer r.background.Done()
+
+		// The refresh outlives the lookup starting it,
+		// so it does not use the context of the lookup.
+		err := r.Refresh(context.Background(), epoch)
+
+		r.lock.Lock()
+		r.refreshing = false
+		r.lock.Unlock()
+
+		if err != nil {
+			log.Printf("level=warning msg=%q error=%q", "could not refresh validator registry", err.Error())
+		}
+	}()
+}
+
+// Refresh appends the validators added since the last
+// refresh, re-indexes changed withdrawal credentials and
+// records epoch as the refresh epoch. The cache lock is
+// only held while updating the cache with each page.
+func (r *ValidatorRegistry) Refresh(ctx context.Context, epoch uint64) error {
+	r.refreshLock.Lock()
+	defer r.refreshLock.Unlock()
+
+	// Another refresh may have completed
+	// while we waited for the lock.
+	r.lock.RLock()
+	fresh := r.refreshed && r.epoch >= epoch
+	known := len(r.pubkeys)
+	r.lock.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	// Prysm page tokens are page numbers. Paging resumes at
+	// the page holding the last known validator because
+	// prysm rejects pages starting past the registry.
+	page := 0
+	if known > 0 {
+		page = (known - 1) / validatorsPageSize
+	}
+
+	pageToken := strconv.Itoa(page)
+	complete := false
+	for known < r.maxValidators {
+		res, err := r.client.ListValidators(ctx, &pb.ListValidatorsRequest{
+			PageSize:  validatorsPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: could not list validators", err)
+		}
+
+		r.lock.Lock()
+		err = r.addPage(res.ValidatorList)
+		known = len(r.pubkeys)
+		r.lock.Unlock()
+		if err != nil {
+			return err
+		}
+
+		if len(res.NextPageToken) == 0 || len(res.ValidatorList) == 0 {
+			// The last page may hold validators
+			// beyond maxValidators.
+			last := len(res.ValidatorList) - 1
+			complete = last < 0 || res.ValidatorList[last].Index < uint64(known)
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+
+	if err := r.refreshCredentials(ctx); err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	r.complete = complete
+	r.refreshed = true
+	r.epoch = epoch
+	r.lock.Unlock()
+
+	return nil
+}
+
+// refreshCredentials re-reads the validators holding BLS
+// withdrawal credentials and re-indexes those whose
+// credentials changed since they were cached.
+func (r *ValidatorRegistry) refreshCredentials(ctx context.Context) error {
+	r.lock.RLock()
+	indices := []uint64{}
+	for index, creds := range r.withdrawals {
+		if creds[0] == BLSWithdrawalPrefix {
+			indices = append(indices, uint64(index))
+		}
+	}
+	r.lock.RUnlock()
+
+	// Prysm paginates filtered results, so indices are requested
+	// in chunks that fit in a single page.
+	for start := 0; start < len(indices); start += validatorsPageSize {
+		end := start + validatorsPageSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+
+		res, err := r.client.ListValidators(ctx, &pb.ListValidatorsRequest{
+			Indices:  indices[start:end],
+			PageSize: validatorsPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: could not list validators", err)
+		}
+
+		r.lock.Lock()
+		for _, container := range res.ValidatorList {
+			r.reindex(container)
+		}
+		r.lock.Unlock()
+	}
+
+	return nil
+}
+
+// reindex moves a cached validator to the withdrawal
+// credentials of container if they changed. The caller
+// must hold the write lock.
+func (r *ValidatorRegistry) reindex(container *pb.Validators_ValidatorContainer) {
+	index := container.Index
+	if index >= uint64(len(r.withdrawals)) || len(container.Validator.GetWithdrawalCredentials()) != rootLength {
+		return
+	}
+
+	var creds withdrawalCredentials
+	copy(creds[:], container.Validator.WithdrawalCredentials)
+
+	previous := r.withdrawals[index]
+	if creds == previous {
+		return
+	}
+
+	// Indices sharing credentials are kept in increasing order.
+	old := r.credentials[previous]
+	i := sort.Search(len(old), func(i int) bool { return old[i] >= index })
+	if i < len(old) && old[i] == index {
+		old = append(old[:i], old[i+1:]...)
+	}
+	if len(old) == 0 {
+		delete(r.credentials, previous)
+	} else {
+		r.credentials[previous] = old
+	}
+
+	updated := r.credentials[creds]
+	i = sort.Search(len(updated), func(i int) bool { return updated[i] >= index })
+	updated = append(updated, 0)
+	copy(updated[i+1:], updated[i:])
+	updated[i] = index
+	r.credentials[creds] = updated
+
+	r.withdrawals[index] = creds
+}
+
+// addPage appends the validators of a page to the
+// registry. The caller must hold the write lock.
+func (r *ValidatorRegistry) addPage(containers []*pb.Validators_ValidatorContainer) error {
+	for _, container := range containers {
+		if err := r.add(container); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// add appends container to the registry,
+// skipping validators that are already known.
+func (r *ValidatorRegistry) add(container *pb.Validators_ValidatorContainer) error {
+	index := uint64(len(r.pubkeys))
+	if container.Index < index || len(r.pubkeys) >= r.maxValidators {
+		return nil
+	}
+
+	if container.Index != index {
+		return fmt.Errorf("%w: expected validator %d but got %d", ErrValidatorRegistryGap, index, container.Index)
+	}
+
+	if len(container.Validator.GetPublicKey()) != PubkeyLength {
+		return fmt.Errorf("%w: validator %d", ErrPubkeyInvalid, container.Index)
+	}
+
+	var key validatorPubkey
+	copy(key[:], container.Validator.PublicKey)
+
+	var creds withdrawalCredentials
+	copy(creds[:], container.Validator.WithdrawalCredentials)
+
+	r.pubkeys = append(r.pubkeys, key)
+	r.withdrawals = append(r.withdrawals, creds)
+	r.indices[key] = index
+	r.credentials[creds] = append(r.credentials[creds], index)
+
+	return nil
+}
+
+// validatorErr converts a GetValidator error,
+// mapping missing validators to ErrValidatorNotFound.
+func validatorErr(err error, validator string) error {
+	switch status.Code(err) {
+	case codes.NotFound, codes.OutOfRange:
+		return fmt.Errorf("%w: %s", ErrValidatorNotFound, validator)
+	default:
+		return fmt.Errorf("%w: could not get validator %s", err, validator)
+	}
+}
@@ -0,0 +1,285 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRegistryClient serves a validator registry of
+// size validators, paginated like prysm.
+type fakeRegistryClient struct {
+	pb.BeaconChainClient
+
+	size int
+
+	// changed overrides the withdrawal
+	// credentials of validators.
+	changed map[uint64][]byte
+
+	// block, when set, holds registry pages
+	// back until it is closed.
+	block chan struct{}
+
+	pageTokens []string
+	lookups    int
+}
+
+func testPubkey(index uint64) []byte {
+	pubkey := make([]byte, PubkeyLength)
+	pubkey[0] = 0xa0
+	binary.BigEndian.PutUint64(pubkey[PubkeyLength-8:], index)
+	return pubkey
+}
+
+// testCredentials returns the withdrawal credentials of
+// the validator at index, shared by every third validator.
+func testCredentials(index uint64) []byte {
+	creds := make([]byte, rootLength)
+	creds[0] = BLSWithdrawalPrefix
+	creds[rootLength-1] = byte(index % 3)
+	return creds
+}
+
+func (c *fakeRegistryClient) container(index uint64) *pb.Validators_ValidatorContainer {
+	creds := testCredentials(index)
+	if changed, ok := c.changed[index]; ok {
+		creds = changed
+	}
+
+	return &pb.Validators_ValidatorContainer{
+		Index: index,
+		Validator: &pb.Validator{
+			PublicKey:             testPubkey(index),
+			WithdrawalCredentials: creds,
+			EffectiveBalance:      32000000000,
+			ExitEpoch:             FarFutureEpoch,
+			WithdrawableEpoch:     FarFutureEpoch,
+		},
+	}
+}
+
+func (c *fakeRegistryClient) ListValidators(
+	ctx context.Context,
+	req *pb.ListValidatorsRequest,
+	opts ...grpc.CallOption,
+) (*pb.Validators, error) {
+	if len(req.PublicKeys) > 0 {
+		c.lookups++
+		res := &pb.Validators{}
+		for i := 0; i < c.size; i++ {
+			if string(testPubkey(uint64(i))) == string(req.PublicKeys[0]) {
+				res.ValidatorList = append(res.ValidatorList, c.container(uint64(i)))
+			}
+		}
+		return res, nil
+	}
+
+	if len(req.Indices) > 0 {
+		res := &pb.Validators{}
+		for _, index := range req.Indices {
+			res.ValidatorList = append(res.ValidatorList, c.container(index))
+		}
+		return res, nil
+	}
+
+	if c.block != nil {
+		<-c.block
+	}
+
+	c.pageTokens = append(c.pageTokens, req.PageToken)
+	page, err := strconv.Atoi(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	start := page * int(req.PageSize)
+	if start >= c.size {
+		return nil, status.Errorf(codes.Internal, "page start %d >= list %d", start, c.size)
+	}
+
+	end := start + int(req.PageSize)
+	nextPageToken := strconv.Itoa(page + 1)
+	if end >= c.size {
+		end = c.size
+		nextPageToken = ""
+	}
+
+	res := &pb.Validators{NextPageToken: nextPageToken, TotalSize: int32(c.size)}
+	for i := start; i < end; i++ {
+		res.ValidatorList = append(res.ValidatorList, c.container(uint64(i)))
+	}
+
+	return res, nil
+}
+
+func (c *fakeRegistryClient) GetValidator(
+	ctx context.Context,
+	req *pb.GetValidatorRequest,
+	opts ...grpc.CallOption,
+) (*pb.Validator, error) {
+	c.lookups++
+	index := req.GetIndex()
+	if index >= uint64(c.size) {
+		return nil, status.Errorf(codes.OutOfRange, "requesting index %d, but there are only %d validators", index, c.size)
+	}
+
+	return c.container(index).Validator, nil
+}
+
+func TestValidatorRegistry(t *testing.T) {
+	ctx := context.Background()
+	genesis := time.Unix(MainnetNetworkConfig.GenesisTime, 0)
+	epochDuration := 384 * time.Second
+
+	t.Run("incremental refresh", func(t *testing.T) {
+		client := &fakeRegistryClient{size: 600, block: make(chan struct{})}
+		registry := NewValidatorRegistry(client, MainnetNetworkConfig, DefaultValidatorRegistrySize)
+		now := genesis.Add(10 * epochDuration)
+		registry.now = func() time.Time { return now }
+
+		// Lookups made while the registry is loading
+		// are served by the beacon node.
+		pubkey, err := registry.Pubkey(ctx, 599)
+		assert.NoError(t, err)
+		assert.Equal(t, testPubkey(599), pubkey)
+		assert.Equal(t, 1, client.lookups)
+
+		close(client.block)
+		registry.background.Wait()
+		assert.Equal(t, 600, registry.Len())
+		assert.Equal(t, []string{"0", "1", "2"}, client.pageTokens)
+
+		// New deposits are not visible until the next
+		// epoch, but are still resolved by the beacon node.
+		client.size = 760
+		index, err := registry.Index(ctx, testPubkey(123))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(123), index)
+		pubkey, err = registry.Pubkey(ctx, 700)
+		assert.NoError(t, err)
+		assert.Equal(t, testPubkey(700), pubkey)
+		registry.background.Wait()
+		assert.Equal(t, 600, registry.Len())
+		assert.Equal(t, 2, client.lookups)
+
+		client.block = make(chan struct{})
+		now = now.Add(epochDuration)
+		index, err = registry.Index(ctx, testPubkey(759))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(759), index)
+		assert.Equal(t, 3, client.lookups)
+
+		close(client.block)
+		registry.background.Wait()
+		assert.Equal(t, 760, registry.Len())
+		assert.Equal(t, []string{"0", "1", "2", "2", "3"}, client.pageTokens)
+
+		index, err = registry.Index(ctx, testPubkey(759))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(759), index)
+		assert.Equal(t, 3, client.lookups)
+	})
+
+	t.Run("warm", func(t *testing.T) {
+		client := &fakeRegistryClient{size: 300}
+		registry := NewValidatorRegistry(client, MainnetNetworkConfig, DefaultValidatorRegistrySize)
+
+		registry.Warm()
+		registry.Warm()
+		registry.background.Wait()
+		assert.Equal(t, 300, registry.Len())
+		assert.Equal(t, []string{"0", "1"}, client.pageTokens)
+	})
+
+	t.Run("refresh at a page boundary", func(t *testing.T) {
+		client := &fakeRegistryClient{size: 500}
+		registry := NewValidatorRegistry(client, MainnetNetworkConfig, DefaultValidatorRegistrySize)
+		assert.NoError(t, registry.Refresh(ctx, 1))
+		assert.NoError(t, registry.Refresh(ctx, 1))
+		assert.NoError(t, registry.Refresh(ctx, 2))
+		assert.Equal(t, 500, registry.Len())
+		assert.Equal(t, []string{"0", "1", "1"}, client.pageTokens)
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		client := &fakeRegistryClient{size: 600}
+		registry := NewValidatorRegistry(client, MainnetNetworkConfig, 300)
+		assert.NoError(t, registry.Refresh(ctx, MainnetNetworkConfig.Epoch(time.Now())))
+		assert.Equal(t, 300, registry.Len())
+		assert.Equal(t, []string{"0", "1"}, client.pageTokens)
+
+		pubkey, err := registry.Pubkey(ctx, 450)
+		assert.NoError(t, err)
+		assert.Equal(t, testPubkey(450), pubkey)
+
+		index, err := registry.Index(ctx, testPubkey(450))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(450), index)
+		assert.Equal(t, 2, client.lookups)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := &fakeRegistryClient{size: 10}
+		registry := NewValidatorRegistry(client, MainnetNetworkConfig, DefaultValidatorRegistrySize)
+
+		pubkey, err := registry.Pubkey(ctx, 10)
+		assert.Nil(t, pubkey)
+		assert.True(t, errors.Is(err, ErrValidatorNotFound))
+
+		_, err = registry.Index(ctx, testPubkey(10))
+		assert.True(t, errors.Is(err, ErrValidatorNotFound))
+
+		_, err = registry.Index(ctx, []byte{0x01})
+		assert.True(t, errors.Is(err, ErrPubkeyInvalid))
+	})
+
+	t.Run("withdrawal credentials", func(t *testing.T) {
+		client := &fakeRegistryClient{size: 10}
+		registry := NewValidatorRegistry(client, MainnetNetworkConfig, DefaultValidatorRegistrySize)
+		registry.now = func() time.Time { return genesis.Add(10 * epochDuration) }
+
+		indices, err := registry.WithdrawalIndices(ctx, testCredentials(1))
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{1, 4, 7}, indices)
+
+		unknown := testCredentials(0)
+		unknown[rootLength-1] = 0xff
+		indices, err = registry.WithdrawalIndices(ctx, unknown)
+		assert.NoError(t, err)
+		assert.Empty(t, indices)
+
+		_, err = registry.WithdrawalIndices(ctx, []byte{0x01})
+		assert.True(t, errors.Is(err, ErrWithdrawalCredentialsInvalid))
+
+		// Credentials changed to an execution address
+		// are re-indexed by the next refresh.
+		execution := make([]byte, rootLength)
+		execution[0] = ExecutionWithdrawalPrefix
+		execution[rootLength-1] = 0x04
+		client.changed = map[uint64][]byte{4: execution}
+		registry.now = func() time.Time { return genesis.Add(11 * epochDuration) }
+
+		indices, err = registry.WithdrawalIndices(ctx, testCredentials(1))
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{1, 7}, indices)
+		indices, err = registry.WithdrawalIndices(ctx, execution)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{4}, indices)
+
+		// A bounded registry cannot find every
+		// validator sharing credentials.
+		bounded := NewValidatorRegistry(client, MainnetNetworkConfig, 5)
+		_, err = bounded.WithdrawalIndices(ctx, testCredentials(1))
+		assert.True(t, errors.Is(err, ErrValidatorRegistryIncomplete))
+	})
+}
@@ -4,14 +4,24 @@ require (
 	github.com/OneOfOne/xxhash v1.2.5 // indirect
 	github.com/coinbase/rosetta-ethereum v0.0.4
 	github.com/coinbase/rosetta-sdk-go v0.6.5
+	github.com/ethereum/go-ethereum v1.9.24
 	github.com/fatih/color v1.10.0
+	github.com/ferranbt/fastssz v0.0.0-20200728110133-0b6e349af87a
 	github.com/go-kit/kit v0.9.0 // indirect
 	github.com/gogo/protobuf v1.3.1
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/prysmaticlabs/ethereumapis v0.0.0-20201207010723-e69ac7fa952d
 	github.com/spf13/cobra v1.1.1
-	github.com/stretchr/testify v1.6.1
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.25.0
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.25.0
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
 	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
-	google.golang.org/grpc v1.29.1
+	google.golang.org/grpc v1.41.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
 
 go 1.15
@@ -5,9 +5,17 @@ package mocks
 import (
 	context "context"
 
+	ethereum "rosetta-ethereum-2.0/ethereum"
+
 	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/coinbase/rosetta-sdk-go/types"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+
+	v1alpha1 "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -15,6 +23,29 @@ type Client struct {
 	mock.Mock
 }
 
+// Balance provides a mock function with given fields: _a0, _a1
+func (_m *Client) Balance(_a0 context.Context, _a1 *types.AccountIdentifier) (*types.AccountBalanceResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *types.AccountBalanceResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *types.AccountIdentifier) *types.AccountBalanceResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.AccountBalanceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.AccountIdentifier) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Block provides a mock function with given fields: _a0, _a1
 func (_m *Client) Block(_a0 context.Context, _a1 *types.PartialBlockIdentifier) (*types.Block, error) {
 	ret := _m.Called(_a0, _a1)
@@ -38,8 +69,137 @@ func (_m *Client) Block(_a0 context.Context, _a1 *types.PartialBlockIdentifier)
 	return r0, r1
 }
 
+// Call provides a mock function with given fields: _a0, _a1
+func (_m *Client) Call(_a0 context.Context, _a1 *types.CallRequest) (*types.CallResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *types.CallResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CallRequest) *types.CallResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CallResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.CallRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DepositContract provides a mock function with given fields: _a0
+func (_m *Client) DepositContract(_a0 context.Context) (common.Address, error) {
+	ret := _m.Called(_a0)
+
+	var r0 common.Address
+	if rf, ok := ret.Get(0).(func(context.Context) common.Address); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fork provides a mock function with given fields: _a0
+func (_m *Client) Fork(_a0 context.Context) (*ethereum.Fork, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *ethereum.Fork
+	if rf, ok := ret.Get(0).(func(context.Context) *ethereum.Fork); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethereum.Fork)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mempool provides a mock function with given fields: _a0
+func (_m *Client) Mempool(_a0 context.Context) ([]*types.TransactionIdentifier, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*types.TransactionIdentifier
+	if rf, ok := ret.Get(0).(func(context.Context) []*types.TransactionIdentifier); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.TransactionIdentifier)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MempoolTransaction provides a mock function with given fields: _a0, _a1
+func (_m *Client) MempoolTransaction(_a0 context.Context, _a1 *types.TransactionIdentifier) (*types.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *types.TransactionIdentifier) *types.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.TransactionIdentifier) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendTransaction provides a mock function with given fields: _a0, _a1
+func (_m *Client) SendTransaction(_a0 context.Context, _a1 *coretypes.Transaction) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Status provides a mock function with given fields: _a0
-func (_m *Client) Status(_a0 context.Context) (*types.BlockIdentifier, int64, *types.SyncStatus, []*types.Peer, error) {
+func (_m *Client) Status(_a0 context.Context) (*types.BlockIdentifier, *types.BlockIdentifier, int64, *types.SyncStatus, []*types.Peer, error) {
 	ret := _m.Called(_a0)
 
 	var r0 *types.BlockIdentifier
@@ -51,37 +211,113 @@ func (_m *Client) Status(_a0 context.Context) (*types.BlockIdentifier, int64, *t
 		}
 	}
 
-	var r1 int64
-	if rf, ok := ret.Get(1).(func(context.Context) int64); ok {
+	var r1 *types.BlockIdentifier
+	if rf, ok := ret.Get(1).(func(context.Context) *types.BlockIdentifier); ok {
 		r1 = rf(_a0)
 	} else {
-		r1 = ret.Get(1).(int64)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.BlockIdentifier)
+		}
 	}
 
-	var r2 *types.SyncStatus
-	if rf, ok := ret.Get(2).(func(context.Context) *types.SyncStatus); ok {
+	var r2 int64
+	if rf, ok := ret.Get(2).(func(context.Context) int64); ok {
 		r2 = rf(_a0)
 	} else {
-		if ret.Get(2) != nil {
-			r2 = ret.Get(2).(*types.SyncStatus)
-		}
+		r2 = ret.Get(2).(int64)
 	}
 
-	var r3 []*types.Peer
-	if rf, ok := ret.Get(3).(func(context.Context) []*types.Peer); ok {
+	var r3 *types.SyncStatus
+	if rf, ok := ret.Get(3).(func(context.Context) *types.SyncStatus); ok {
 		r3 = rf(_a0)
 	} else {
 		if ret.Get(3) != nil {
-			r3 = ret.Get(3).([]*types.Peer)
+			r3 = ret.Get(3).(*types.SyncStatus)
 		}
 	}
 
-	var r4 error
-	if rf, ok := ret.Get(4).(func(context.Context) error); ok {
+	var r4 []*types.Peer
+	if rf, ok := ret.Get(4).(func(context.Context) []*types.Peer); ok {
 		r4 = rf(_a0)
 	} else {
-		r4 = ret.Error(4)
+		if ret.Get(4) != nil {
+			r4 = ret.Get(4).([]*types.Peer)
+		}
+	}
+
+	var r5 error
+	if rf, ok := ret.Get(5).(func(context.Context) error); ok {
+		r5 = rf(_a0)
+	} else {
+		r5 = ret.Error(5)
+	}
+
+	return r0, r1, r2, r3, r4, r5
+}
+
+// SubmitVoluntaryExit provides a mock function with given fields: _a0, _a1
+func (_m *Client) SubmitVoluntaryExit(_a0 context.Context, _a1 *v1alpha1.SignedVoluntaryExit) ([]byte, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.SignedVoluntaryExit) []byte); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1alpha1.SignedVoluntaryExit) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransactionParams provides a mock function with given fields: _a0, _a1
+func (_m *Client) TransactionParams(_a0 context.Context, _a1 common.Address) (*ethereum.TransactionParams, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ethereum.TransactionParams
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *ethereum.TransactionParams); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethereum.TransactionParams)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2, r3, r4
+	return r0, r1
+}
+
+// ValidatorIndex provides a mock function with given fields: _a0, _a1
+func (_m *Client) ValidatorIndex(_a0 context.Context, _a1 []byte) (uint64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) uint64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
@@ -2,16 +2,29 @@ package services
 
 import (
 	"context"
+	"errors"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
 // AccountAPIService implements the server.AccountAPIServicer interface.
-type AccountAPIService struct{}
+type AccountAPIService struct {
+	config  *configuration.Configuration
+	clients Clients
+}
 
 // NewAccountAPIService returns a new *AccountAPIService.
-func NewAccountAPIService() *AccountAPIService {
-	return &AccountAPIService{}
+func NewAccountAPIService(
+	cfg *configuration.Configuration,
+	clients Clients,
+) *AccountAPIService {
+	return &AccountAPIService{
+		config:  cfg,
+		clients: clients,
+	}
 }
 
 // AccountBalance implements /account/balance.
@@ -19,7 +32,26 @@ func (s *AccountAPIService) AccountBalance(
 	ctx context.Context,
 	request *types.AccountBalanceRequest,
 ) (*types.AccountBalanceResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	balance, err := client.Balance(ctx, request.AccountIdentifier)
+	switch {
+	case errors.Is(err, ethereum.ErrInvalidAddress):
+		return nil, wrapErr(ErrInvalidAddress, err)
+	case errors.Is(err, ethereum.ErrSubAccountInvalid):
+		return nil, wrapErr(ErrSubAccountInvalid, err)
+	case err != nil:
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	return balance, nil
 }
 
 // AccountCoins implements /account/coins.
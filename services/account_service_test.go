@@ -2,23 +2,101 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+	mocks "rosetta-ethereum-2.0/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestAccountBalance(t *testing.T) {
-	servicer := NewAccountAPIService()
-
+func TestAccountService_Offline(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Offline,
+		Networks: networks,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewAccountAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
 	ctx := context.Background()
 
-	bal, err := servicer.AccountBalance(ctx, nil)
+	bal, err := servicer.AccountBalance(ctx, &types.AccountBalanceRequest{NetworkIdentifier: networkIdentifier})
 	assert.Nil(t, bal)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
 
-	coins, err := servicer.AccountCoins(ctx, nil)
+	coins, err := servicer.AccountCoins(ctx, &types.AccountCoinsRequest{NetworkIdentifier: networkIdentifier})
 	assert.Nil(t, coins)
 	assert.Equal(t, ErrUnimplemented.Code, err.Code)
 	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestAccountService_Online(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+	}
+	ctx := context.Background()
+
+	account := &types.AccountIdentifier{
+		Address: "0xa0",
+		SubAccount: &types.SubAccountIdentifier{
+			Address: ethereum.EffectiveBalanceSubAccount,
+		},
+	}
+	balance := &types.AccountBalanceResponse{
+		BlockIdentifier: &types.BlockIdentifier{Index: 100, Hash: "block 100"},
+		Balances:        []*types.Amount{ethereum.Amount(32000000000)},
+		Metadata:        map[string]interface{}{"status": ethereum.ValidatorStatusActive},
+	}
+
+	tests := map[string]struct {
+		balance *types.AccountBalanceResponse
+		err     error
+
+		expectedErr *types.Error
+	}{
+		"balance": {
+			balance: balance,
+		},
+		"invalid address": {
+			err:         fmt.Errorf("%w: 0xa0", ethereum.ErrInvalidAddress),
+			expectedErr: ErrInvalidAddress,
+		},
+		"invalid sub-account": {
+			err:         fmt.Errorf("%w: locked", ethereum.ErrSubAccountInvalid),
+			expectedErr: ErrSubAccountInvalid,
+		},
+		"beacon error": {
+			err:         fmt.Errorf("connection refused"),
+			expectedErr: ErrBeacon,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &mocks.Client{}
+			servicer := NewAccountAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
+			mockClient.On("Balance", ctx, account).Return(test.balance, test.err).Once()
+
+			bal, err := servicer.AccountBalance(ctx, &types.AccountBalanceRequest{
+				NetworkIdentifier: networkIdentifier,
+				AccountIdentifier: account,
+			})
+			if test.expectedErr != nil {
+				assert.Nil(t, bal)
+				assert.Equal(t, test.expectedErr.Code, err.Code)
+				assert.Equal(t, test.err.Error(), err.Details["context"])
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.balance, bal)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
 }
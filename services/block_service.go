@@ -2,26 +2,28 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
 // BlockAPIService implements the server.BlockAPIServicer interface.
 type BlockAPIService struct {
-	config *configuration.Configuration
-	client Client
+	config  *configuration.Configuration
+	clients Clients
 }
 
 // NewBlockAPIService creates a new instance of a BlockAPIService.
 func NewBlockAPIService(
 	cfg *configuration.Configuration,
-	client Client,
+	clients Clients,
 ) *BlockAPIService {
 	return &BlockAPIService{
-		config: cfg,
-		client: client,
+		config:  cfg,
+		clients: clients,
 	}
 }
 
@@ -34,7 +36,15 @@ func (s *BlockAPIService) Block(
 		return nil, ErrUnavailableOffline
 	}
 
-	block, err := s.client.Block(ctx, request.BlockIdentifier)
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	block, err := client.Block(ctx, request.BlockIdentifier)
+	if errors.Is(err, ethereum.ErrBlockOrphaned) {
+		return nil, wrapErr(ErrBlockOrphaned, err)
+	}
 	if err != nil {
 		return nil, wrapErr(ErrBeacon, err)
 	}
@@ -14,13 +14,14 @@ import (
 
 func TestBlockService_Offline(t *testing.T) {
 	cfg := &configuration.Configuration{
-		Mode: configuration.Offline,
+		Mode:     configuration.Offline,
+		Networks: networks,
 	}
 	mockClient := &mocks.Client{}
-	servicer := NewBlockAPIService(cfg, mockClient)
+	servicer := NewBlockAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
 	ctx := context.Background()
 
-	block, err := servicer.Block(ctx, &types.BlockRequest{})
+	block, err := servicer.Block(ctx, &types.BlockRequest{NetworkIdentifier: networkIdentifier})
 	assert.Nil(t, block)
 	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
 	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
@@ -35,10 +36,11 @@ func TestBlockService_Offline(t *testing.T) {
 
 func TestBlockService_Online(t *testing.T) {
 	cfg := &configuration.Configuration{
-		Mode: configuration.Online,
+		Mode:     configuration.Online,
+		Networks: networks,
 	}
 	mockClient := &mocks.Client{}
-	servicer := NewBlockAPIService(cfg, mockClient)
+	servicer := NewBlockAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
 	ctx := context.Background()
 
 	block := &types.Block{
@@ -61,7 +63,7 @@ func TestBlockService_Online(t *testing.T) {
 			block,
 			nil,
 		).Once()
-		b, err := servicer.Block(ctx, &types.BlockRequest{})
+		b, err := servicer.Block(ctx, &types.BlockRequest{NetworkIdentifier: networkIdentifier})
 		assert.Nil(t, err)
 		assert.Equal(t, blockResponse, b)
 	})
@@ -70,7 +72,8 @@ func TestBlockService_Online(t *testing.T) {
 		pbIdentifier := types.ConstructPartialBlockIdentifier(block.BlockIdentifier)
 		mockClient.On("Block", ctx, pbIdentifier).Return(block, nil).Once()
 		b, err := servicer.Block(ctx, &types.BlockRequest{
-			BlockIdentifier: pbIdentifier,
+			NetworkIdentifier: networkIdentifier,
+			BlockIdentifier:   pbIdentifier,
 		})
 		assert.Nil(t, err)
 		assert.Equal(t, blockResponse, b)
@@ -80,7 +83,8 @@ func TestBlockService_Online(t *testing.T) {
 		pbIdentifier := types.ConstructPartialBlockIdentifier(block.BlockIdentifier)
 		mockClient.On("Block", ctx, pbIdentifier).Return(nil, ethereum.ErrBlockOrphaned).Once()
 		b, err := servicer.Block(ctx, &types.BlockRequest{
-			BlockIdentifier: pbIdentifier,
+			NetworkIdentifier: networkIdentifier,
+			BlockIdentifier:   pbIdentifier,
 		})
 
 		assert.Nil(t, b)
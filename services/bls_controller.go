@@ -0,0 +1,247 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"rosetta-ethereum-2.0/ethereum"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// BLSConstructionAPIController serves the construction endpoints
+// taking BLS12-381 public keys and signatures, which the asserter
+// of rosetta-sdk-go v0.6.5 rejects. Requests are asserted like the
+// SDK does, except that ethereum.BLS12381CurveType is accepted as
+// a curve type and ethereum.BLS12381SignatureType as a signature
+// type.
+//
+// It must be passed to server.NewRouter before the SDK
+// construction controller so its routes take precedence.
+type BLSConstructionAPIController struct {
+	service  server.ConstructionAPIServicer
+	asserter *asserter.Asserter
+}
+
+// NewBLSConstructionAPIController creates a new
+// BLSConstructionAPIController.
+func NewBLSConstructionAPIController(
+	s server.ConstructionAPIServicer,
+	asserter *asserter.Asserter,
+) server.Router {
+	return &BLSConstructionAPIController{
+		service:  s,
+		asserter: asserter,
+	}
+}
+
+// Routes returns the routes overridden by
+// the BLSConstructionAPIController.
+func (c *BLSConstructionAPIController) Routes() server.Routes {
+	return server.Routes{
+		{
+			Name:        "ConstructionCombine",
+			Method:      http.MethodPost,
+			Pattern:     "/construction/combine",
+			HandlerFunc: c.ConstructionCombine,
+		},
+		{
+			Name:        "ConstructionDerive",
+			Method:      http.MethodPost,
+			Pattern:     "/construction/derive",
+			HandlerFunc: c.ConstructionDerive,
+		},
+	}
+}
+
+// ConstructionCombine - Create Network Transaction from Signatures
+func (c *BLSConstructionAPIController) ConstructionCombine(w http.ResponseWriter, r *http.Request) {
+	constructionCombineRequest := &types.ConstructionCombineRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&constructionCombineRequest); err != nil {
+		server.EncodeJSONResponse(&types.Error{
+			Message: err.Error(),
+		}, http.StatusInternalServerError, w)
+
+		return
+	}
+
+	if err := c.assertConstructionCombineRequest(constructionCombineRequest); err != nil {
+		server.EncodeJSONResponse(&types.Error{
+			Message: err.Error(),
+		}, http.StatusInternalServerError, w)
+
+		return
+	}
+
+	result, serviceErr := c.service.ConstructionCombine(r.Context(), constructionCombineRequest)
+	if serviceErr != nil {
+		server.EncodeJSONResponse(serviceErr, http.StatusInternalServerError, w)
+
+		return
+	}
+
+	server.EncodeJSONResponse(result, http.StatusOK, w)
+}
+
+// ConstructionDerive - Derive an AccountIdentifier from a PublicKey
+func (c *BLSConstructionAPIController) ConstructionDerive(w http.ResponseWriter, r *http.Request) {
+	constructionDeriveRequest := &types.ConstructionDeriveRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&constructionDeriveRequest); err != nil {
+		server.EncodeJSONResponse(&types.Error{
+			Message: err.Error(),
+		}, http.StatusInternalServerError, w)
+
+		return
+	}
+
+	if err := c.assertConstructionDeriveRequest(constructionDeriveRequest); err != nil {
+		server.EncodeJSONResponse(&types.Error{
+			Message: err.Error(),
+		}, http.StatusInternalServerError, w)
+
+		return
+	}
+
+	result, serviceErr := c.service.ConstructionDerive(r.Context(), constructionDeriveRequest)
+	if serviceErr != nil {
+		server.EncodeJSONResponse(serviceErr, http.StatusInternalServerError, w)
+
+		return
+	}
+
+	server.EncodeJSONResponse(result, http.StatusOK, w)
+}
+
+// assertConstructionDeriveRequest mirrors
+// asserter.ConstructionDeriveRequest.
+func (c *BLSConstructionAPIController) assertConstructionDeriveRequest(
+	request *types.ConstructionDeriveRequest,
+) error {
+	if c.asserter == nil {
+		return asserter.ErrAsserterNotInitialized
+	}
+
+	if request == nil {
+		return asserter.ErrConstructionDeriveRequestIsNil
+	}
+
+	if err := c.asserter.ValidSupportedNetwork(request.NetworkIdentifier); err != nil {
+		return err
+	}
+
+	return assertPublicKey(request.PublicKey)
+}
+
+// assertConstructionCombineRequest mirrors
+// asserter.ConstructionCombineRequest.
+func (c *BLSConstructionAPIController) assertConstructionCombineRequest(
+	request *types.ConstructionCombineRequest,
+) error {
+	if c.asserter == nil {
+		return asserter.ErrAsserterNotInitialized
+	}
+
+	if request == nil {
+		return asserter.ErrConstructionCombineRequestIsNil
+	}
+
+	if err := c.asserter.ValidSupportedNetwork(request.NetworkIdentifier); err != nil {
+		return err
+	}
+
+	if len(request.UnsignedTransaction) == 0 {
+		return asserter.ErrConstructionCombineRequestUnsignedTxEmpty
+	}
+
+	return assertSignatures(request.Signatures)
+}
+
+// assertSignatures mirrors asserter.Signatures,
+// also accepting BLS12-381 signatures.
+func assertSignatures(signatures []*types.Signature) error {
+	if len(signatures) == 0 {
+		return asserter.ErrSignaturesEmpty
+	}
+
+	for i, signature := range signatures {
+		if err := assertSigningPayload(signature.SigningPayload); err != nil {
+			return fmt.Errorf("%w: signature %d has invalid signing payload", err, i)
+		}
+
+		if err := assertPublicKey(signature.PublicKey); err != nil {
+			return fmt.Errorf("%w: signature %d has invalid public key", err, i)
+		}
+
+		if err := assertSignatureType(signature.SignatureType); err != nil {
+			return fmt.Errorf("%w: signature %d has invalid signature type", err, i)
+		}
+
+		if len(signature.SigningPayload.SignatureType) > 0 &&
+			signature.SigningPayload.SignatureType != signature.SignatureType {
+			return asserter.ErrSignaturesReturnedSigMismatch
+		}
+
+		if len(signature.Bytes) == 0 {
+			return fmt.Errorf("%w: signature %d has 0 bytes", asserter.ErrSignatureBytesEmpty, i)
+		}
+
+		if asserter.BytesArrayZero(signature.Bytes) {
+			return asserter.ErrSignatureBytesZero
+		}
+	}
+
+	return nil
+}
+
+// assertSigningPayload mirrors asserter.SigningPayload,
+// also accepting BLS12-381 signatures.
+func assertSigningPayload(signingPayload *types.SigningPayload) error {
+	if signingPayload == nil || signingPayload.SignatureType != ethereum.BLS12381SignatureType {
+		return asserter.SigningPayload(signingPayload)
+	}
+
+	if err := asserter.AccountIdentifier(signingPayload.AccountIdentifier); err != nil {
+		return fmt.Errorf("%w: %s", asserter.ErrSigningPayloadAddrEmpty, err)
+	}
+
+	if len(signingPayload.Bytes) == 0 {
+		return asserter.ErrSigningPayloadBytesEmpty
+	}
+
+	if asserter.BytesArrayZero(signingPayload.Bytes) {
+		return asserter.ErrSigningPayloadBytesZero
+	}
+
+	return nil
+}
+
+// assertSignatureType mirrors asserter.SignatureType,
+// also accepting BLS12-381 signatures.
+func assertSignatureType(signatureType types.SignatureType) error {
+	if signatureType == ethereum.BLS12381SignatureType {
+		return nil
+	}
+
+	return asserter.SignatureType(signatureType)
+}
+
+// assertPublicKey mirrors asserter.PublicKey,
+// also accepting BLS12-381 public keys.
+func assertPublicKey(publicKey *types.PublicKey) error {
+	if publicKey == nil || publicKey.CurveType != ethereum.BLS12381CurveType {
+		return asserter.PublicKey(publicKey)
+	}
+
+	if len(publicKey.Bytes) == 0 {
+		return asserter.ErrPublicKeyBytesEmpty
+	}
+
+	if asserter.BytesArrayZero(publicKey.Bytes) {
+		return asserter.ErrPublicKeyBytesZero
+	}
+
+	return nil
+}
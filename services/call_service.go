@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// CallAPIService implements the server.CallAPIServicer interface.
+type CallAPIService struct {
+	config  *configuration.Configuration
+	clients Clients
+}
+
+// NewCallAPIService creates a new instance of a CallAPIService.
+func NewCallAPIService(
+	cfg *configuration.Configuration,
+	clients Clients,
+) *CallAPIService {
+	return &CallAPIService{
+		config:  cfg,
+		clients: clients,
+	}
+}
+
+// Call implements the /call endpoint.
+func (s *CallAPIService) Call(
+	ctx context.Context,
+	request *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	response, err := client.Call(ctx, request)
+	switch {
+	case errors.Is(err, ethereum.ErrCallParametersInvalid):
+		return nil, wrapErr(ErrCallParametersInvalid, err)
+	case errors.Is(err, ethereum.ErrCallOutputMarshal):
+		return nil, wrapErr(ErrCallOutputMarshal, err)
+	case errors.Is(err, ethereum.ErrCallMethodInvalid):
+		return nil, wrapErr(ErrCallMethodInvalid, err)
+	case errors.Is(err, ethereum.ErrWeb3ProviderUnavailable):
+		return nil, wrapErr(ErrWeb3Provider, err)
+	case err != nil:
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+	mocks "rosetta-ethereum-2.0/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallService_Offline(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Offline,
+		Networks: networks,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewCallAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
+
+	response, err := servicer.Call(context.Background(), &types.CallRequest{
+		NetworkIdentifier: networkIdentifier,
+		Method:            ethereum.ChainConfigMethod,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCallService_Online(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		response *types.CallResponse
+		err      error
+
+		expectedErr *types.Error
+	}{
+		"success": {
+			response: &types.CallResponse{
+				Result: map[string]interface{}{
+					"finalized": map[string]interface{}{"epoch": "98", "root": "98"},
+				},
+			},
+		},
+		"invalid parameters": {
+			err:         fmt.Errorf("%w: epoch \"-1\" is not a decimal uint64", ethereum.ErrCallParametersInvalid),
+			expectedErr: ErrCallParametersInvalid,
+		},
+		"output marshal": {
+			err:         fmt.Errorf("%w: unsupported value", ethereum.ErrCallOutputMarshal),
+			expectedErr: ErrCallOutputMarshal,
+		},
+		"invalid method": {
+			err:         fmt.Errorf("%w: eth_call", ethereum.ErrCallMethodInvalid),
+			expectedErr: ErrCallMethodInvalid,
+		},
+		"no web3 provider": {
+			err:         ethereum.ErrWeb3ProviderUnavailable,
+			expectedErr: ErrWeb3Provider,
+		},
+		"beacon node error": {
+			err:         errors.New("connection refused"),
+			expectedErr: ErrBeacon,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &mocks.Client{}
+			servicer := NewCallAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
+			request := &types.CallRequest{
+				NetworkIdentifier: networkIdentifier,
+				Method:            ethereum.FinalityCheckpointsMethod,
+			}
+			mockClient.On("Call", ctx, request).Return(test.response, test.err).Once()
+
+			response, err := servicer.Call(ctx, request)
+			if test.expectedErr != nil {
+				assert.Nil(t, response)
+				assert.Equal(t, test.expectedErr.Code, err.Code)
+				assert.Equal(t, test.err.Error(), err.Details["context"])
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.response, response)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown network", func(t *testing.T) {
+		servicer := NewCallAPIService(cfg, Clients{ethereum.MainnetNetwork: &mocks.Client{}})
+
+		response, err := servicer.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{Blockchain: ethereum.Blockchain, Network: "Medalla"},
+			Method:            ethereum.ChainConfigMethod,
+		})
+		assert.Nil(t, response)
+		assert.Equal(t, ErrNetworkNotSupported.Code, err.Code)
+	})
+}
@@ -2,24 +2,60 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
 // ConstructionAPIService implements the server.ConstructionAPIServicer interface.
-type ConstructionAPIService struct{}
+//
+// Only /construction/metadata and /construction/submit
+// query the beacon node; all other endpoints work offline.
+// Transactions are JSON objects whose "type" is the
+// operation type of their intent.
+type ConstructionAPIService struct {
+	config  *configuration.Configuration
+	clients Clients
+}
 
 // NewConstructionAPIService creates a new instance of a ConstructionAPIService.
-func NewConstructionAPIService() *ConstructionAPIService {
-	return &ConstructionAPIService{}
+func NewConstructionAPIService(
+	cfg *configuration.Configuration,
+	clients Clients,
+) *ConstructionAPIService {
+	return &ConstructionAPIService{
+		config:  cfg,
+		clients: clients,
+	}
 }
 
 // ConstructionDerive implements the /construction/derive endpoint.
+// It derives the validator account of a BLS12-381 public key
+// and does not need a beacon node.
 func (s *ConstructionAPIService) ConstructionDerive(
 	ctx context.Context,
 	request *types.ConstructionDeriveRequest,
 ) (*types.ConstructionDeriveResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if request.PublicKey.CurveType != ethereum.BLS12381CurveType {
+		return nil, wrapErr(
+			ErrUnableToDecompressPubkey,
+			fmt.Errorf("%s is not a supported curve type", request.PublicKey.CurveType),
+		)
+	}
+
+	if err := ethereum.ValidatePubkey(request.PublicKey.Bytes); err != nil {
+		return nil, wrapErr(ErrUnableToDecompressPubkey, err)
+	}
+
+	return &types.ConstructionDeriveResponse{
+		AccountIdentifier: &types.AccountIdentifier{
+			Address: ethereum.ValidatorAddress(request.PublicKey.Bytes),
+		},
+	}, nil
 }
 
 // ConstructionPreprocess implements the /construction/preprocess
@@ -28,7 +64,26 @@ func (s *ConstructionAPIService) ConstructionPreprocess(
 	ctx context.Context,
 	request *types.ConstructionPreprocessRequest,
 ) (*types.ConstructionPreprocessResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	var preprocessOutput *options
+	var rErr *types.Error
+	switch intentType(request.Operations) {
+	case ethereum.DepositOpType:
+		preprocessOutput, rErr = preprocessDeposit(request.Operations)
+	default:
+		preprocessOutput, rErr = preprocessVoluntaryExit(request.Operations)
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	marshaled, err := marshalJSONMap(preprocessOutput)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionPreprocessResponse{
+		Options: marshaled,
+	}, nil
 }
 
 // ConstructionMetadata implements the /construction/metadata endpoint.
@@ -36,7 +91,42 @@ func (s *ConstructionAPIService) ConstructionMetadata(
 	ctx context.Context,
 	request *types.ConstructionMetadataRequest,
 ) (*types.ConstructionMetadataResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	var input options
+	if err := unmarshalJSONMap(request.Options, &input); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+	network := s.networkConfig(request.NetworkIdentifier)
+
+	var metadata interface{}
+	switch input.Type {
+	case ethereum.VoluntaryExitOpType:
+		metadata, rErr = voluntaryExitMetadata(ctx, client, network, &input)
+	case ethereum.DepositOpType:
+		metadata, rErr = depositMetadataFor(ctx, client, network, &input)
+	default:
+		rErr = wrapErr(ErrUnclearIntent, fmt.Errorf("%s is not supported", input.Type))
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	metadataMap, err := marshalJSONMap(metadata)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionMetadataResponse{
+		Metadata: metadataMap,
+	}, nil
 }
 
 // ConstructionPayloads implements the /construction/payloads endpoint.
@@ -44,7 +134,13 @@ func (s *ConstructionAPIService) ConstructionPayloads(
 	ctx context.Context,
 	request *types.ConstructionPayloadsRequest,
 ) (*types.ConstructionPayloadsResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	network := s.networkConfig(request.NetworkIdentifier)
+	switch intentType(request.Operations) {
+	case ethereum.DepositOpType:
+		return depositPayloads(network, request.Operations, request.Metadata)
+	default:
+		return voluntaryExitPayloads(network, request.Operations, request.Metadata)
+	}
 }
 
 // ConstructionCombine implements the /construction/combine
@@ -53,15 +149,62 @@ func (s *ConstructionAPIService) ConstructionCombine(
 	ctx context.Context,
 	request *types.ConstructionCombineRequest,
 ) (*types.ConstructionCombineResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	txType, rErr := transactionType(request.UnsignedTransaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	network := s.networkConfig(request.NetworkIdentifier)
+
+	var signedTx interface{}
+	switch txType {
+	case ethereum.DepositOpType:
+		signedTx, rErr = combineDeposit(network, request.UnsignedTransaction, request.Signatures)
+	default:
+		signedTx, rErr = combineVoluntaryExit(network, request.UnsignedTransaction, request.Signatures)
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	signedTxJSON, err := json.Marshal(signedTx)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionCombineResponse{
+		SignedTransaction: string(signedTxJSON),
+	}, nil
 }
 
 // ConstructionHash implements the /construction/hash endpoint.
+// Voluntary exits are identified by their hash tree root and
+// deposits by the hash of the transaction funding them.
 func (s *ConstructionAPIService) ConstructionHash(
 	ctx context.Context,
 	request *types.ConstructionHashRequest,
 ) (*types.TransactionIdentifierResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	txType, rErr := transactionType(request.SignedTransaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var hash string
+	switch txType {
+	case ethereum.DepositOpType:
+		hash, rErr = hashDeposit(request.SignedTransaction)
+	default:
+		hash, rErr = hashVoluntaryExit(request.SignedTransaction)
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	return &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{
+			Hash: hash,
+		},
+	}, nil
 }
 
 // ConstructionParse implements the /construction/parse endpoint.
@@ -69,7 +212,17 @@ func (s *ConstructionAPIService) ConstructionParse(
 	ctx context.Context,
 	request *types.ConstructionParseRequest,
 ) (*types.ConstructionParseResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	txType, rErr := transactionType(request.Transaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	switch txType {
+	case ethereum.DepositOpType:
+		return parseDeposit(request.Transaction, request.Signed)
+	default:
+		return parseVoluntaryExit(request.Transaction, request.Signed)
+	}
 }
 
 // ConstructionSubmit implements the /construction/submit endpoint.
@@ -77,5 +230,82 @@ func (s *ConstructionAPIService) ConstructionSubmit(
 	ctx context.Context,
 	request *types.ConstructionSubmitRequest,
 ) (*types.TransactionIdentifierResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	txType, rErr := transactionType(request.SignedTransaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var hash string
+	switch txType {
+	case ethereum.DepositOpType:
+		hash, rErr = submitDeposit(ctx, client, request.SignedTransaction)
+	default:
+		hash, rErr = submitVoluntaryExit(ctx, client, request.SignedTransaction)
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	return &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{
+			Hash: hash,
+		},
+	}, nil
+}
+
+// intentType returns the operation type of the intent
+// described by operations, which all share it.
+func intentType(operations []*types.Operation) string {
+	if len(operations) == 0 {
+		return ""
+	}
+
+	return operations[0].Type
+}
+
+// transactionType returns the type of an
+// unsigned or signed transaction.
+func transactionType(transaction string) (string, *types.Error) {
+	var tx struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(transaction), &tx); err != nil {
+		return "", wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	switch tx.Type {
+	case ethereum.VoluntaryExitOpType, ethereum.DepositOpType:
+		return tx.Type, nil
+	default:
+		return "", wrapErr(
+			ErrUnableToParseIntermediateResult,
+			fmt.Errorf("%q is not a supported transaction type", tx.Type),
+		)
+	}
+}
+
+// networkConfig returns the *ethereum.NetworkConfig of
+// the served network identified by identifier, or nil
+// if it is not served or has no known configuration.
+func (s *ConstructionAPIService) networkConfig(identifier *types.NetworkIdentifier) *ethereum.NetworkConfig {
+	if identifier == nil {
+		return nil
+	}
+
+	for _, network := range s.config.Networks {
+		if network.Identifier != nil && network.Identifier.Network == identifier.Network {
+			return network.Config
+		}
+	}
+
+	return nil
 }
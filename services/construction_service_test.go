@@ -2,52 +2,1039 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+	mocks "rosetta-ethereum-2.0/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	bls12381 "github.com/kilic/bls12-381"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	offlineConfig = &configuration.Configuration{
+		Mode:     configuration.Offline,
+		Networks: networks,
+	}
+
+	onlineConfig = &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+	}
 )
 
-func TestConstructionService(t *testing.T) {
-	servicer := NewConstructionAPIService()
+// g1Generator is the compressed generator of G1,
+// a valid BLS12-381 public key.
+const g1Generator = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+
+// blsSign returns the signature of root by the secret key
+// of g1Generator, which is 1: the hash of root to G2.
+func blsSign(root []byte) []byte {
+	g2 := bls12381.NewG2()
+	point, err := g2.HashToCurve(root, []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"))
+	if err != nil {
+		panic(err)
+	}
+	return g2.ToCompressed(point)
+}
+
+func TestConstructionService_Derive(t *testing.T) {
+	servicer := NewConstructionAPIService(offlineConfig, Clients{})
 	ctx := context.Background()
 
-	deriveResponse, err := servicer.ConstructionDerive(ctx, nil)
-	assert.Nil(t, deriveResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	tests := map[string]struct {
+		publicKey *types.PublicKey
 
-	preprocessResponse, err := servicer.ConstructionPreprocess(ctx, nil)
-	assert.Nil(t, preprocessResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+		expectedAccount *types.AccountIdentifier
+		expectedErr     *types.Error
+	}{
+		"validator": {
+			publicKey: &types.PublicKey{
+				Bytes:     mustDecodeHex(g1Generator),
+				CurveType: ethereum.BLS12381CurveType,
+			},
+			expectedAccount: &types.AccountIdentifier{Address: "0x" + g1Generator},
+		},
+		"invalid point": {
+			publicKey: &types.PublicKey{
+				Bytes:     mustDecodeHex("80" + strings.Repeat("00", 46) + "01"),
+				CurveType: ethereum.BLS12381CurveType,
+			},
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+		"unsupported curve": {
+			publicKey: &types.PublicKey{
+				Bytes:     mustDecodeHex(g1Generator),
+				CurveType: types.Secp256k1,
+			},
+			expectedErr: ErrUnableToDecompressPubkey,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := servicer.ConstructionDerive(ctx, &types.ConstructionDeriveRequest{
+				NetworkIdentifier: networkIdentifier,
+				PublicKey:         test.publicKey,
+			})
+			if test.expectedErr != nil {
+				assert.Nil(t, response)
+				assert.Equal(t, test.expectedErr.Code, err.Code)
+				assert.NotEmpty(t, err.Details["context"])
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expectedAccount, response.AccountIdentifier)
+			}
+		})
+	}
+}
+
+// exitSigningRoot returns the hex-encoded signing root of
+// the voluntary exit of validator index at epoch under
+// the hex-encoded domain.
+func exitSigningRoot(index uint64, epoch uint64, domain string) string {
+	var d [32]byte
+	copy(d[:], mustDecodeHex(domain))
+	root := ethereum.ComputeSigningRoot(
+		ethereum.VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: epoch, ValidatorIndex: index}),
+		d,
+	)
+	return hex.EncodeToString(root[:])
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
 
-	metadataResponse, err := servicer.ConstructionMetadata(ctx, nil)
+func TestConstructionService_VoluntaryExit(t *testing.T) {
+	ctx := context.Background()
+	pubkey := mustDecodeHex(g1Generator)
+	address := "0x" + g1Generator
+
+	// Offline endpoints are served without any
+	// client, so they cannot reach a beacon node.
+	offline := NewConstructionAPIService(offlineConfig, Clients{})
+
+	mockClient := &mocks.Client{}
+	online := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: mockClient})
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                ethereum.VoluntaryExitOpType,
+			Account:             &types.AccountIdentifier{Address: address},
+		},
+	}
+
+	// Preprocess
+	preprocessResponse, rErr := offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, map[string]interface{}{
+		"type":             ethereum.VoluntaryExitOpType,
+		"validator_pubkey": address,
+	}, preprocessResponse.Options)
+
+	// Metadata
+	metadataResponse, rErr := offline.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           preprocessResponse.Options,
+	})
 	assert.Nil(t, metadataResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
-
-	payloadsResponse, err := servicer.ConstructionPayloads(ctx, nil)
-	assert.Nil(t, payloadsResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
-
-	combineResponse, err := servicer.ConstructionCombine(ctx, nil)
-	assert.Nil(t, combineResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
-
-	hashResponse, err := servicer.ConstructionHash(ctx, nil)
-	assert.Nil(t, hashResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
-
-	parseResponse, err := servicer.ConstructionParse(ctx, nil)
-	assert.Nil(t, parseResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
-
-	submitResponse, err := servicer.ConstructionSubmit(ctx, nil)
-	assert.Nil(t, submitResponse)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, rErr.Code)
+
+	fork := &ethereum.Fork{
+		Epoch:          194048,
+		CurrentVersion: [ethereum.ForkVersionLength]byte{0x00, 0x00, 0x00, 0x00},
+	}
+	copy(fork.GenesisValidatorsRoot[:], mustDecodeHex(strings.Repeat("4b", 32)))
+	mockClient.On("ValidatorIndex", ctx, pubkey).Return(uint64(1234), nil).Once()
+	mockClient.On("Fork", ctx).Return(fork, nil).Once()
+	metadataResponse, rErr = online.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           preprocessResponse.Options,
+	})
+	assert.Nil(t, rErr)
+	metadata := map[string]interface{}{
+		"validator_index":         "1234",
+		"epoch":                   "194048",
+		"fork_version":            "0x00000000",
+		"genesis_validators_root": "0x" + strings.Repeat("4b", 32),
+	}
+	assert.Equal(t, metadata, metadataResponse.Metadata)
+	assert.Empty(t, metadataResponse.SuggestedFee)
+
+	// Payloads
+	payloadsResponse, rErr := offline.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          metadataResponse.Metadata,
+	})
+	assert.Nil(t, rErr)
+
+	domain := ethereum.ComputeDomain(ethereum.DomainVoluntaryExit, fork.CurrentVersion, fork.GenesisValidatorsRoot)
+	exitRoot := ethereum.VoluntaryExitRoot(&pb.VoluntaryExit{Epoch: 194048, ValidatorIndex: 1234})
+	signingRoot := ethereum.ComputeSigningRoot(exitRoot, domain)
+	assert.Equal(t, []*types.SigningPayload{
+		{
+			AccountIdentifier: &types.AccountIdentifier{Address: address},
+			Bytes:             signingRoot[:],
+			SignatureType:     ethereum.BLS12381SignatureType,
+		},
+	}, payloadsResponse.Payloads)
+
+	// Parse unsigned
+	parseResponse, rErr := offline.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	parsedOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                ethereum.VoluntaryExitOpType,
+			Account:             &types.AccountIdentifier{Address: address},
+			Metadata: map[string]interface{}{
+				"validator_index": "1234",
+				"epoch":           "194048",
+			},
+		},
+	}
+	assert.Equal(t, parsedOps, parseResponse.Operations)
+	assert.Empty(t, parseResponse.AccountIdentifierSigners)
+	assert.Equal(t, metadata, parseResponse.Metadata)
+
+	// Combine
+	signature := &types.Signature{
+		SigningPayload: payloadsResponse.Payloads[0],
+		PublicKey: &types.PublicKey{
+			Bytes:     pubkey,
+			CurveType: ethereum.BLS12381CurveType,
+		},
+		SignatureType: ethereum.BLS12381SignatureType,
+		Bytes:         blsSign(payloadsResponse.Payloads[0].Bytes),
+	}
+	combineResponse, rErr := offline.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          []*types.Signature{signature},
+	})
+	assert.Nil(t, rErr)
+
+	var signedTx map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(combineResponse.SignedTransaction), &signedTx))
+	assert.Equal(t, encodeHex(signature.Bytes), signedTx["signature"])
+
+	// Parse signed
+	parseResponse, rErr = offline.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, parsedOps, parseResponse.Operations)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: address}}, parseResponse.AccountIdentifierSigners)
+
+	// Signed and unsigned transactions are not interchangeable.
+	_, rErr = offline.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Equal(t, ErrUnableToParseIntermediateResult.Code, rErr.Code)
+
+	// Hash
+	hashResponse, rErr := offline.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, hex.EncodeToString(exitRoot[:]), hashResponse.TransactionIdentifier.Hash)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_VoluntaryExitErrors(t *testing.T) {
+	ctx := context.Background()
+	address := "0x" + g1Generator
+	offline := NewConstructionAPIService(offlineConfig, Clients{})
+
+	t.Run("unclear intent", func(t *testing.T) {
+		_, rErr := offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                ethereum.VoluntaryExitOpType,
+					Account:             &types.AccountIdentifier{Address: address},
+				},
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 1},
+					Type:                ethereum.VoluntaryExitOpType,
+					Account:             &types.AccountIdentifier{Address: address},
+				},
+			},
+		})
+		assert.Equal(t, ErrUnclearIntent.Code, rErr.Code)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, rErr := offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                ethereum.VoluntaryExitOpType,
+					Account:             &types.AccountIdentifier{Address: "0x1234"},
+				},
+			},
+		})
+		assert.Equal(t, ErrInvalidAddress.Code, rErr.Code)
+	})
+
+	t.Run("requested epoch", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		online := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: mockClient})
+
+		preprocessResponse, rErr := offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                ethereum.VoluntaryExitOpType,
+					Account:             &types.AccountIdentifier{Address: address},
+					Metadata:            map[string]interface{}{"epoch": "200000"},
+				},
+			},
+		})
+		assert.Nil(t, rErr)
+
+		mockClient.On("ValidatorIndex", ctx, mustDecodeHex(g1Generator)).Return(uint64(1), nil).Once()
+		mockClient.On("Fork", ctx).Return(&ethereum.Fork{Epoch: 10}, nil).Once()
+		metadataResponse, rErr := online.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, rErr)
+		assert.Equal(t, "200000", metadataResponse.Metadata["epoch"])
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fork version of the requested epoch", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		forked := NewConstructionAPIService(&configuration.Configuration{
+			Mode: configuration.Online,
+			Networks: []*configuration.Network{
+				{
+					Identifier: networkIdentifier,
+					Config:     ethereum.MainnetNetworkConfig,
+				},
+			},
+		}, Clients{ethereum.MainnetNetwork: mockClient})
+
+		tests := map[string]struct {
+			epoch string
+
+			expectedEpoch       string
+			expectedForkVersion string
+		}{
+			"head epoch": {
+				expectedEpoch:       "10",
+				expectedForkVersion: "0x00000000",
+			},
+			"epoch after altair": {
+				epoch:               "74240",
+				expectedEpoch:       "74240",
+				expectedForkVersion: "0x01000000",
+			},
+			"epoch after capella": {
+				epoch:               "194048",
+				expectedEpoch:       "194048",
+				expectedForkVersion: "0x03000000",
+			},
+			"epoch after deneb": {
+				epoch:               "269568",
+				expectedEpoch:       "269568",
+				expectedForkVersion: "0x03000000",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				options := map[string]interface{}{
+					"type":             ethereum.VoluntaryExitOpType,
+					"validator_pubkey": address,
+				}
+				if len(test.epoch) > 0 {
+					options["epoch"] = test.epoch
+				}
+
+				mockClient.On("ValidatorIndex", ctx, mustDecodeHex(g1Generator)).Return(uint64(1), nil).Once()
+				mockClient.On("Fork", ctx).Return(&ethereum.Fork{Epoch: 10}, nil).Once()
+				metadataResponse, rErr := forked.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+					NetworkIdentifier: networkIdentifier,
+					Options:           options,
+				})
+				assert.Nil(t, rErr)
+				assert.Equal(t, test.expectedEpoch, metadataResponse.Metadata["epoch"])
+				assert.Equal(t, test.expectedForkVersion, metadataResponse.Metadata["fork_version"])
+				mockClient.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		online := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: mockClient})
+
+		err := fmt.Errorf("%w: %s", ethereum.ErrValidatorNotFound, g1Generator)
+		mockClient.On("ValidatorIndex", ctx, mustDecodeHex(g1Generator)).Return(uint64(0), err).Once()
+		_, rErr := online.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options: map[string]interface{}{
+				"type":             ethereum.VoluntaryExitOpType,
+				"validator_pubkey": address,
+			},
+		})
+		assert.Equal(t, ErrInvalidAddress.Code, rErr.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("network domain", func(t *testing.T) {
+		mainnet := NewConstructionAPIService(&configuration.Configuration{
+			Mode: configuration.Offline,
+			Networks: []*configuration.Network{
+				{Identifier: networkIdentifier, Config: ethereum.MainnetNetworkConfig},
+			},
+		}, Clients{})
+
+		tests := map[string]struct {
+			genesisValidatorsRoot string
+
+			expectedPayload string
+			expectedErr     *types.Error
+		}{
+			"mainnet fork": {
+				genesisValidatorsRoot: "0x" + ethereum.MainnetNetworkConfig.GenesisValidatorsRoot,
+				expectedPayload:       exitSigningRoot(1, 2, "04000000b5303f2ad2010d699a76c8e62350947421a3e4a979779642cfdb0f66"),
+			},
+			"fork of another network": {
+				genesisValidatorsRoot: "0x" + ethereum.TestnetNetworkConfig.GenesisValidatorsRoot,
+				expectedErr:           ErrUnableToParseIntermediateResult,
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				payloadsResponse, rErr := mainnet.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+					NetworkIdentifier: networkIdentifier,
+					Operations: []*types.Operation{
+						{
+							OperationIdentifier: &types.OperationIdentifier{Index: 0},
+							Type:                ethereum.VoluntaryExitOpType,
+							Account:             &types.AccountIdentifier{Address: address},
+						},
+					},
+					Metadata: map[string]interface{}{
+						"validator_index":         "1",
+						"epoch":                   "2",
+						"fork_version":            "0x00000000",
+						"genesis_validators_root": test.genesisValidatorsRoot,
+					},
+				})
+				if test.expectedErr != nil {
+					assert.Nil(t, payloadsResponse)
+					assert.Equal(t, test.expectedErr.Code, rErr.Code)
+				} else {
+					assert.Nil(t, rErr)
+					assert.Equal(t, test.expectedPayload, hex.EncodeToString(payloadsResponse.Payloads[0].Bytes))
+				}
+			})
+		}
+	})
+
+	t.Run("signature does not verify", func(t *testing.T) {
+		unsignedTx := `{"type":"VOLUNTARY_EXIT","validator_pubkey":"` + address + `","validator_index":"1","epoch":"2",` +
+			`"fork_version":"0x00000000","genesis_validators_root":"0x` + strings.Repeat("00", 32) + `"}`
+		payloadsResponse, rErr := offline.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                ethereum.VoluntaryExitOpType,
+					Account:             &types.AccountIdentifier{Address: address},
+				},
+			},
+			Metadata: map[string]interface{}{
+				"validator_index":         "1",
+				"epoch":                   "2",
+				"fork_version":            "0x00000000",
+				"genesis_validators_root": "0x" + strings.Repeat("00", 32),
+			},
+		})
+		assert.Nil(t, rErr)
+		payload := payloadsResponse.Payloads[0]
+
+		tests := map[string]struct {
+			payload   *types.SigningPayload
+			pubkey    string
+			signature []byte
+		}{
+			"signature of another root": {
+				payload:   payload,
+				pubkey:    g1Generator,
+				signature: blsSign(make([]byte, 32)),
+			},
+			"signature of another payload": {
+				payload: &types.SigningPayload{
+					AccountIdentifier: payload.AccountIdentifier,
+					Bytes:             make([]byte, 32),
+				},
+				pubkey:    g1Generator,
+				signature: blsSign(make([]byte, 32)),
+			},
+			"signature by another key": {
+				payload:   payload,
+				pubkey:    "a0" + strings.Repeat("00", 47),
+				signature: blsSign(payload.Bytes),
+			},
+			"signature not on the curve": {
+				payload:   payload,
+				pubkey:    g1Generator,
+				signature: mustDecodeHex(strings.Repeat("a1", ethereum.SignatureLength)),
+			},
+			"short signature": {
+				payload:   payload,
+				pubkey:    g1Generator,
+				signature: blsSign(payload.Bytes)[:48],
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, rErr := offline.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+					NetworkIdentifier:   networkIdentifier,
+					UnsignedTransaction: unsignedTx,
+					Signatures: []*types.Signature{
+						{
+							SigningPayload: test.payload,
+							PublicKey: &types.PublicKey{
+								Bytes:     mustDecodeHex(test.pubkey),
+								CurveType: ethereum.BLS12381CurveType,
+							},
+							SignatureType: ethereum.BLS12381SignatureType,
+							Bytes:         test.signature,
+						},
+					},
+				})
+				assert.Equal(t, ErrSignatureInvalid.Code, rErr.Code)
+				assert.Equal(t, address, rErr.Details["account"])
+				assert.Equal(t, encodeHex(payload.Bytes), rErr.Details["payload"])
+				assert.NotEmpty(t, rErr.Details["context"])
+			})
+		}
+	})
+}
+
+func TestConstructionService_Submit(t *testing.T) {
+	ctx := context.Background()
+	signature := strings.Repeat("a1", ethereum.SignatureLength)
+	signedTx := `{"type":"VOLUNTARY_EXIT","validator_pubkey":"0x` + g1Generator + `","validator_index":"1234","epoch":"194048",` +
+		`"fork_version":"0x00000000","genesis_validators_root":"0x` + strings.Repeat("4b", 32) + `",` +
+		`"signature":"0x` + signature + `"}`
+	exit := &pb.SignedVoluntaryExit{
+		Exit:      &pb.VoluntaryExit{Epoch: 194048, ValidatorIndex: 1234},
+		Signature: mustDecodeHex(signature),
+	}
+	exitRoot := ethereum.VoluntaryExitRoot(exit.Exit)
+
+	t.Run("offline", func(t *testing.T) {
+		servicer := NewConstructionAPIService(offlineConfig, Clients{})
+
+		submitResponse, rErr := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: signedTx,
+		})
+		assert.Nil(t, submitResponse)
+		assert.Equal(t, ErrUnavailableOffline.Code, rErr.Code)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		servicer := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: &mocks.Client{}})
+
+		unsignedTx := strings.Replace(signedTx, `,"signature":"0x`+signature+`"`, "", 1)
+		submitResponse, rErr := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: unsignedTx,
+		})
+		assert.Nil(t, submitResponse)
+		assert.Equal(t, ErrUnableToParseIntermediateResult.Code, rErr.Code)
+	})
+
+	tests := map[string]struct {
+		err error
+
+		expectedErr *types.Error
+	}{
+		"accepted": {},
+		"already exiting": {
+			err:         fmt.Errorf("%w: validator with index 1234 has already submitted an exit", ethereum.ErrValidatorAlreadyExiting),
+			expectedErr: ErrValidatorAlreadyExiting,
+		},
+		"too early": {
+			err:         fmt.Errorf("%w: expected current epoch >= exit epoch", ethereum.ErrExitTooEarly),
+			expectedErr: ErrExitTooEarly,
+		},
+		"not active": {
+			err:         fmt.Errorf("%w: non-active validator cannot exit", ethereum.ErrValidatorNotActive),
+			expectedErr: ErrValidatorNotActive,
+		},
+		"invalid signature": {
+			err:         fmt.Errorf("%w: signature did not verify", ethereum.ErrExitSignatureInvalid),
+			expectedErr: ErrSignatureInvalid,
+		},
+		"unknown validator": {
+			err:         fmt.Errorf("%w: validator index exceeds validator set length", ethereum.ErrValidatorNotFound),
+			expectedErr: ErrInvalidAddress,
+		},
+		"other rejection": {
+			err:         fmt.Errorf("%w: nil exit", ethereum.ErrExitRejected),
+			expectedErr: ErrBroadcastFailed,
+		},
+		"beacon node error": {
+			err:         errors.New("connection refused"),
+			expectedErr: ErrBroadcastFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &mocks.Client{}
+			servicer := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: mockClient})
+
+			var root []byte
+			if test.err == nil {
+				root = exitRoot[:]
+			}
+			mockClient.On("SubmitVoluntaryExit", ctx, exit).Return(root, test.err).Once()
+
+			submitResponse, rErr := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+				NetworkIdentifier: networkIdentifier,
+				SignedTransaction: signedTx,
+			})
+			if test.expectedErr != nil {
+				assert.Nil(t, submitResponse)
+				assert.Equal(t, test.expectedErr.Code, rErr.Code)
+				assert.Equal(t, test.err.Error(), rErr.Details["context"])
+			} else {
+				assert.Nil(t, rErr)
+				assert.Equal(t, &types.TransactionIdentifier{
+					Hash: hex.EncodeToString(exitRoot[:]),
+				}, submitResponse.TransactionIdentifier)
+
+				// The submitted exit is identified
+				// like by /construction/hash.
+				hashResponse, rErr := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+					NetworkIdentifier: networkIdentifier,
+					SignedTransaction: signedTx,
+				})
+				assert.Nil(t, rErr)
+				assert.Equal(t, submitResponse.TransactionIdentifier, hashResponse.TransactionIdentifier)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConstructionService_Deposit(t *testing.T) {
+	ctx := context.Background()
+	pubkey := mustDecodeHex(g1Generator)
+	validator := "0x" + g1Generator
+	creds := "0x00" + strings.Repeat("00", 30) + "01"
+
+	fundingKey, err := crypto.HexToECDSA(strings.Repeat("46", 32))
+	assert.NoError(t, err)
+	from := crypto.PubkeyToAddress(fundingKey.PublicKey).Hex()
+
+	offline := NewConstructionAPIService(offlineConfig, Clients{})
+	mockClient := &mocks.Client{}
+	online := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: mockClient})
+
+	ops := func(opMetadata map[string]interface{}) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                ethereum.DepositOpType,
+				Account:             &types.AccountIdentifier{Address: from},
+				Amount:              &types.Amount{Value: "-32000000000000000000", Currency: ethereum.Currency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				Type:                ethereum.DepositOpType,
+				Account:             &types.AccountIdentifier{Address: validator},
+				Amount:              &types.Amount{Value: "32000000000000000000", Currency: ethereum.Currency},
+				Metadata:            opMetadata,
+			},
+		}
+	}
+	fork := &ethereum.Fork{GenesisVersion: [ethereum.ForkVersionLength]byte{0x00, 0x00, 0x10, 0x20}}
+	messageRoot := ethereum.DepositMessageRoot(pubkey, mustDecodeHex(creds[2:]), 32000000000)
+	signingRoot := ethereum.ComputeSigningRoot(messageRoot, ethereum.DepositDomain(fork.GenesisVersion))
+	blsSignature := encodeHex(blsSign(signingRoot[:]))
+
+	// Round 1: the validator signs the deposit data.
+	dataOps := ops(map[string]interface{}{"withdrawal_credentials": creds})
+	preprocessResponse, rErr := offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        dataOps,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, map[string]interface{}{
+		"type":             ethereum.DepositOpType,
+		"validator_pubkey": validator,
+	}, preprocessResponse.Options)
+
+	mockClient.On("Fork", ctx).Return(fork, nil).Once()
+	metadataResponse, rErr := online.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           preprocessResponse.Options,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, map[string]interface{}{"fork_version": "0x00001020"}, metadataResponse.Metadata)
+
+	payloadsResponse, rErr := offline.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        dataOps,
+		Metadata:          metadataResponse.Metadata,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, []*types.SigningPayload{
+		{
+			AccountIdentifier: &types.AccountIdentifier{Address: validator},
+			Bytes:             signingRoot[:],
+			SignatureType:     ethereum.BLS12381SignatureType,
+		},
+	}, payloadsResponse.Payloads)
+
+	combineResponse, rErr := offline.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey:      &types.PublicKey{Bytes: pubkey, CurveType: ethereum.BLS12381CurveType},
+				SignatureType:  ethereum.BLS12381SignatureType,
+				Bytes:          mustDecodeHex(blsSignature[2:]),
+			},
+		},
+	})
+	assert.Nil(t, rErr)
+
+	parseResponse, rErr := offline.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	fundingOps := ops(map[string]interface{}{"withdrawal_credentials": creds, "signature": blsSignature})
+	assert.Equal(t, fundingOps, parseResponse.Operations)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: validator}}, parseResponse.AccountIdentifierSigners)
+
+	// Signed deposit data is not a transaction yet.
+	_, rErr = offline.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Equal(t, ErrUnableToParseIntermediateResult.Code, rErr.Code)
+
+	// Round 2: the funding account signs the deposit
+	// contract call including the deposit data.
+	preprocessResponse, rErr = offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        parseResponse.Operations,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, map[string]interface{}{
+		"type":             ethereum.DepositOpType,
+		"validator_pubkey": validator,
+		"from":             from,
+	}, preprocessResponse.Options)
+
+	contract := common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	mockClient.On("Fork", ctx).Return(fork, nil).Once()
+	mockClient.On("DepositContract", ctx).Return(contract, nil).Once()
+	mockClient.On("TransactionParams", ctx, common.HexToAddress(from)).Return(&ethereum.TransactionParams{
+		Nonce:    3,
+		GasPrice: big.NewInt(20000000000),
+		ChainID:  big.NewInt(1),
+	}, nil).Once()
+	metadataResponse, rErr = online.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           preprocessResponse.Options,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, map[string]interface{}{
+		"fork_version":     "0x00001020",
+		"deposit_contract": contract.Hex(),
+		"nonce":            "3",
+		"gas_price":        "20000000000",
+		"chain_id":         "1",
+	}, metadataResponse.Metadata)
+
+	payloadsResponse, rErr = offline.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        fundingOps,
+		Metadata:          metadataResponse.Metadata,
+	})
+	assert.Nil(t, rErr)
+	assert.Len(t, payloadsResponse.Payloads, 1)
+	assert.Equal(t, &types.AccountIdentifier{Address: from}, payloadsResponse.Payloads[0].AccountIdentifier)
+	assert.Equal(t, types.EcdsaRecovery, payloadsResponse.Payloads[0].SignatureType)
+
+	parseResponse, rErr = offline.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, fundingOps, parseResponse.Operations)
+	assert.Empty(t, parseResponse.AccountIdentifierSigners)
+
+	signature, err := crypto.Sign(payloadsResponse.Payloads[0].Bytes, fundingKey)
+	assert.NoError(t, err)
+	combineResponse, rErr = offline.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&fundingKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         signature,
+			},
+		},
+	})
+	assert.Nil(t, rErr)
+
+	parseResponse, rErr = offline.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, fundingOps, parseResponse.Operations)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: from}}, parseResponse.AccountIdentifierSigners)
+
+	hashResponse, rErr := offline.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, rErr)
+
+	// The deposit contract call carries the deposit.
+	mockClient.On("SendTransaction", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		tx := args.Get(1).(*gethTypes.Transaction)
+		assert.Equal(t, hashResponse.TransactionIdentifier.Hash, tx.Hash().Hex())
+		assert.Equal(t, &contract, tx.To())
+		assert.Equal(t, ethereum.Wei(32000000000), tx.Value())
+		assert.Equal(t, uint64(3), tx.Nonce())
+
+		input, err := ethereum.DepositInput(&pb.Deposit_Data{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: mustDecodeHex(creds[2:]),
+			Amount:                32000000000,
+			Signature:             mustDecodeHex(blsSignature[2:]),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, input, tx.Data())
+	}).Once()
+	submitResponse, rErr := online.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, rErr)
+	assert.Equal(t, hashResponse.TransactionIdentifier, submitResponse.TransactionIdentifier)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_DepositErrors(t *testing.T) {
+	ctx := context.Background()
+	offline := NewConstructionAPIService(offlineConfig, Clients{})
+	from := "0x57f75C3e5b3C8A8fE0eaF23a7Dc1cAd0F1BcF6C8"
+
+	tests := map[string]struct {
+		validator string
+		amount    string
+		metadata  map[string]interface{}
+
+		expectedErr *types.Error
+	}{
+		"below minimum deposit": {
+			validator:   "0x" + g1Generator,
+			amount:      "500000000000000000",
+			metadata:    map[string]interface{}{"withdrawal_credentials": "0x" + strings.Repeat("00", 32)},
+			expectedErr: ErrUnclearIntent,
+		},
+		"invalid withdrawal credentials": {
+			validator:   "0x" + g1Generator,
+			amount:      "32000000000000000000",
+			metadata:    map[string]interface{}{"withdrawal_credentials": "0x02" + strings.Repeat("00", 31)},
+			expectedErr: ErrUnclearIntent,
+		},
+		"public key not on the curve": {
+			validator:   "0x80" + strings.Repeat("00", 46) + "01",
+			amount:      "32000000000000000000",
+			metadata:    map[string]interface{}{"withdrawal_credentials": "0x" + strings.Repeat("00", 32)},
+			expectedErr: ErrInvalidAddress,
+		},
+		"short signature": {
+			validator: "0x" + g1Generator,
+			amount:    "32000000000000000000",
+			metadata: map[string]interface{}{
+				"withdrawal_credentials": "0x" + strings.Repeat("00", 32),
+				"signature":              "0x" + strings.Repeat("a1", 48),
+			},
+			expectedErr: ErrSignatureInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, rErr := offline.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations: []*types.Operation{
+					{
+						OperationIdentifier: &types.OperationIdentifier{Index: 0},
+						Type:                ethereum.DepositOpType,
+						Account:             &types.AccountIdentifier{Address: from},
+						Amount:              &types.Amount{Value: "-" + test.amount, Currency: ethereum.Currency},
+					},
+					{
+						OperationIdentifier: &types.OperationIdentifier{Index: 1},
+						Type:                ethereum.DepositOpType,
+						Account:             &types.AccountIdentifier{Address: test.validator},
+						Amount:              &types.Amount{Value: test.amount, Currency: ethereum.Currency},
+						Metadata:            test.metadata,
+					},
+				},
+			})
+			assert.Equal(t, test.expectedErr.Code, rErr.Code)
+		})
+	}
+
+	t.Run("deposit data does not verify", func(t *testing.T) {
+		validator := "0x" + g1Generator
+		payloadsResponse, rErr := offline.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                ethereum.DepositOpType,
+					Account:             &types.AccountIdentifier{Address: from},
+					Amount:              &types.Amount{Value: "-32000000000000000000", Currency: ethereum.Currency},
+				},
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 1},
+					Type:                ethereum.DepositOpType,
+					Account:             &types.AccountIdentifier{Address: validator},
+					Amount:              &types.Amount{Value: "32000000000000000000", Currency: ethereum.Currency},
+					Metadata: map[string]interface{}{
+						"withdrawal_credentials": "0x" + strings.Repeat("00", 32),
+						"signature":              encodeHex(blsSign(make([]byte, 32))),
+					},
+				},
+			},
+			Metadata: map[string]interface{}{
+				"fork_version":     "0x00000000",
+				"deposit_contract": "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+				"nonce":            "0",
+				"gas_price":        "1",
+				"chain_id":         "1",
+			},
+		})
+		assert.Nil(t, rErr)
+
+		_, rErr = offline.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   networkIdentifier,
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures: []*types.Signature{
+				{
+					SigningPayload: payloadsResponse.Payloads[0],
+					PublicKey:      &types.PublicKey{Bytes: make([]byte, 33), CurveType: types.Secp256k1},
+					SignatureType:  types.EcdsaRecovery,
+					Bytes:          make([]byte, 65),
+				},
+			},
+		})
+		assert.Equal(t, ErrSignatureInvalid.Code, rErr.Code)
+		assert.Equal(t, validator, rErr.Details["account"])
+	})
+
+	t.Run("no web3 provider", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		online := NewConstructionAPIService(onlineConfig, Clients{ethereum.MainnetNetwork: mockClient})
+
+		mockClient.On("Fork", ctx).Return(&ethereum.Fork{}, nil).Once()
+		mockClient.On("DepositContract", ctx).Return(common.Address{0x01}, nil).Once()
+		mockClient.On("TransactionParams", ctx, common.HexToAddress(from)).
+			Return(nil, ethereum.ErrWeb3ProviderUnavailable).Once()
+		_, rErr := online.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options: map[string]interface{}{
+				"type":             ethereum.DepositOpType,
+				"validator_pubkey": "0x" + g1Generator,
+				"from":             from,
+			},
+		})
+		assert.Equal(t, ErrWeb3Provider.Code, rErr.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("genesis fork version of a known network", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		pyrmont := NewConstructionAPIService(&configuration.Configuration{
+			Mode: configuration.Online,
+			Networks: []*configuration.Network{
+				{Identifier: networkIdentifier, Config: ethereum.TestnetNetworkConfig},
+			},
+		}, Clients{ethereum.MainnetNetwork: mockClient})
+
+		// The fork version is read from the fork
+		// schedule without querying the beacon node.
+		metadataResponse, rErr := pyrmont.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options: map[string]interface{}{
+				"type":             ethereum.DepositOpType,
+				"validator_pubkey": "0x" + g1Generator,
+			},
+		})
+		assert.Nil(t, rErr)
+		assert.Equal(t, map[string]interface{}{"fork_version": "0x00002009"}, metadataResponse.Metadata)
+		mockClient.AssertExpectations(t)
+	})
 }
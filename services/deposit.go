@@ -0,0 +1,617 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"rosetta-ethereum-2.0/ethereum"
+
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// depositDescriptions match the operations of a deposit:
+// the funding account is debited and the validator
+// credited with the same amount.
+var depositDescriptions = &parser.Descriptions{
+	OperationDescriptions: []*parser.OperationDescription{
+		{
+			Type: ethereum.DepositOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists:   true,
+				Sign:     parser.NegativeAmountSign,
+				Currency: ethereum.Currency,
+			},
+		},
+		{
+			Type: ethereum.DepositOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists:   true,
+				Sign:     parser.PositiveAmountSign,
+				Currency: ethereum.Currency,
+			},
+		},
+	},
+	OppositeAmounts: [][]int{{0, 1}},
+	ErrUnmatched:    true,
+}
+
+// preprocessDeposit returns the options of a deposit intent.
+func preprocessDeposit(operations []*types.Operation) (*options, *types.Error) {
+	intent, rErr := matchDeposit(operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	preprocessOutput := &options{
+		Type:            ethereum.DepositOpType,
+		ValidatorPubkey: intent.ValidatorPubkey,
+	}
+	if len(intent.Signature) > 0 {
+		preprocessOutput.From = intent.From
+	}
+
+	return preprocessOutput, nil
+}
+
+// depositMetadataFor looks up the genesis fork version
+// deposits are signed with and, when the deposit data
+// is signed, the parameters of the funding transaction.
+// The genesis fork version is read from the fork schedule
+// of network, or from the beacon node if it has none.
+func depositMetadataFor(
+	ctx context.Context,
+	client Client,
+	network *ethereum.NetworkConfig,
+	input *options,
+) (*depositMetadata, *types.Error) {
+	if _, err := ethereum.ParseValidatorAddress(input.ValidatorPubkey); err != nil {
+		return nil, wrapErr(ErrInvalidAddress, err)
+	}
+
+	var genesisVersion [ethereum.ForkVersionLength]byte
+	if network != nil && len(network.ForkSchedule) > 0 {
+		genesisVersion = network.ForkSchedule.GenesisVersion()
+	} else {
+		fork, err := client.Fork(ctx)
+		if err != nil {
+			return nil, wrapErr(ErrBeacon, err)
+		}
+		genesisVersion = fork.GenesisVersion
+	}
+
+	metadata := &depositMetadata{
+		ForkVersion: encodeHex(genesisVersion[:]),
+	}
+	if len(input.From) == 0 {
+		return metadata, nil
+	}
+
+	if !common.IsHexAddress(input.From) {
+		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", input.From))
+	}
+
+	contract, err := client.DepositContract(ctx)
+	if err != nil {
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	params, err := client.TransactionParams(ctx, common.HexToAddress(input.From))
+	if err != nil {
+		return nil, wrapErr(ErrWeb3Provider, err)
+	}
+
+	metadata.DepositContract = contract.Hex()
+	metadata.Nonce = &params.Nonce
+	metadata.GasPrice = params.GasPrice.String()
+	metadata.ChainID = params.ChainID.String()
+
+	return metadata, nil
+}
+
+// depositPayloads returns the unsigned deposit and the
+// payload signed in its current round: the signing root
+// of the deposit data for the validator, or the funding
+// transaction for the funding account.
+func depositPayloads(
+	network *ethereum.NetworkConfig,
+	operations []*types.Operation,
+	metadataMap map[string]interface{},
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	unsignedTx, rErr := matchDeposit(operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if err := unmarshalJSONMap(metadataMap, &unsignedTx.depositMetadata); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	var payload *types.SigningPayload
+	if len(unsignedTx.Signature) == 0 {
+		// The funding transaction is
+		// constructed in the next round.
+		unsignedTx.depositMetadata = depositMetadata{ForkVersion: unsignedTx.ForkVersion}
+
+		signingRoot, err := unsignedTx.signingRoot(network)
+		if err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+
+		payload = &types.SigningPayload{
+			AccountIdentifier: &types.AccountIdentifier{Address: unsignedTx.ValidatorPubkey},
+			Bytes:             signingRoot[:],
+			SignatureType:     ethereum.BLS12381SignatureType,
+		}
+	} else {
+		if len(unsignedTx.DepositContract) == 0 {
+			return nil, wrapErr(
+				ErrUnableToParseIntermediateResult,
+				errors.New("metadata has no funding transaction parameters"),
+			)
+		}
+
+		tx, signer, err := unsignedTx.transaction()
+		if err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+
+		payload = &types.SigningPayload{
+			AccountIdentifier: &types.AccountIdentifier{Address: unsignedTx.From},
+			Bytes:             signer.Hash(tx).Bytes(),
+			SignatureType:     types.EcdsaRecovery,
+		}
+	}
+
+	unsignedTxJSON, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(unsignedTxJSON),
+		Payloads:            []*types.SigningPayload{payload},
+	}, nil
+}
+
+// combineDeposit attaches the signature of the
+// current round to a deposit.
+func combineDeposit(
+	network *ethereum.NetworkConfig,
+	unsignedTx string,
+	signatures []*types.Signature,
+) (*deposit, *types.Error) {
+	tx, rErr := decodeDeposit(unsignedTx, false)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	signingRoot, err := tx.signingRoot(network)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if !tx.funding() {
+		signature, rErr := blsSignature(signatures, tx.ValidatorPubkey, signingRoot)
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		tx.Signature = encodeHex(signature)
+		return tx, nil
+	}
+
+	// Deposits with an invalid signature are accepted by the
+	// deposit contract but ignored by the beacon chain, so the
+	// deposit data is verified before it is funded.
+	data := tx.data()
+	if err := ethereum.VerifySignature(data.PublicKey, signingRoot, data.Signature); err != nil {
+		return nil, signatureErr(tx.ValidatorPubkey, signingRoot, err)
+	}
+
+	if len(signatures) != 1 {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("expected 1 signature but got %d", len(signatures)),
+		)
+	}
+	if signatures[0].SignatureType != types.EcdsaRecovery {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("%s is not a supported signature type", signatures[0].SignatureType),
+		)
+	}
+
+	fundingTx, signer, err := tx.transaction()
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	signedTx, err := fundingTx.WithSignature(signer, signatures[0].Bytes)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	sender, err := gethTypes.Sender(signer, signedTx)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+	if sender != common.HexToAddress(tx.From) {
+		return nil, wrapErr(ErrSignatureInvalid, fmt.Errorf("signature is not by %s", tx.From))
+	}
+
+	raw, err := rlp.EncodeToBytes(signedTx)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	tx.Transaction = encodeHex(raw)
+	return tx, nil
+}
+
+// hashDeposit returns the hash of the
+// transaction funding a deposit.
+func hashDeposit(signedTx string) (string, *types.Error) {
+	tx, rErr := decodeDeposit(signedTx, true)
+	if rErr != nil {
+		return "", rErr
+	}
+
+	fundingTx, rErr := tx.signedTransaction()
+	if rErr != nil {
+		return "", rErr
+	}
+
+	return fundingTx.Hash().Hex(), nil
+}
+
+// parseDeposit returns the operations of
+// an unsigned or signed deposit.
+func parseDeposit(transaction string, signed bool) (*types.ConstructionParseResponse, *types.Error) {
+	tx, rErr := decodeDeposit(transaction, signed)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	opMetadata, err := marshalJSONMap(&depositOperationMetadata{
+		WithdrawalCredentials: tx.WithdrawalCredentials,
+		Signature:             tx.Signature,
+	})
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	amount := ethereum.Amount(tx.Amount)
+	from := &types.AccountIdentifier{Address: tx.From}
+	validator := &types.AccountIdentifier{Address: tx.ValidatorPubkey}
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 0,
+			},
+			Type:    ethereum.DepositOpType,
+			Account: from,
+			Amount: &types.Amount{
+				Value:    "-" + amount.Value,
+				Currency: amount.Currency,
+			},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 1,
+			},
+			Type:     ethereum.DepositOpType,
+			Account:  validator,
+			Amount:   amount,
+			Metadata: opMetadata,
+		},
+	}
+
+	metadata, err := marshalJSONMap(&tx.depositMetadata)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	signers := []*types.AccountIdentifier{}
+	switch {
+	case signed && tx.funding():
+		signers = append(signers, from)
+	case signed:
+		signers = append(signers, validator)
+	}
+
+	return &types.ConstructionParseResponse{
+		Operations:               ops,
+		AccountIdentifierSigners: signers,
+		Metadata:                 metadata,
+	}, nil
+}
+
+// submitDeposit broadcasts the transaction funding a
+// deposit through the web3 provider and returns its hash.
+func submitDeposit(ctx context.Context, client Client, signedTx string) (string, *types.Error) {
+	tx, rErr := decodeDeposit(signedTx, true)
+	if rErr != nil {
+		return "", rErr
+	}
+
+	fundingTx, rErr := tx.signedTransaction()
+	if rErr != nil {
+		return "", rErr
+	}
+
+	if err := client.SendTransaction(ctx, fundingTx); err != nil {
+		if errors.Is(err, ethereum.ErrWeb3ProviderUnavailable) {
+			return "", wrapErr(ErrWeb3Provider, err)
+		}
+
+		return "", wrapErr(ErrBroadcastFailed, err)
+	}
+
+	return fundingTx.Hash().Hex(), nil
+}
+
+// matchDeposit returns the unsigned deposit described
+// by the operations of a deposit intent.
+func matchDeposit(operations []*types.Operation) (*deposit, *types.Error) {
+	matches, err := parser.MatchOperations(depositDescriptions, operations)
+	if err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	fromOp, _ := matches[0].First()
+	validatorOp, amount := matches[1].First()
+
+	var opMetadata depositOperationMetadata
+	if err := unmarshalJSONMap(validatorOp.Metadata, &opMetadata); err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	gwei, err := ethereum.DepositAmount(amount)
+	if err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	tx := &deposit{
+		Type:                  ethereum.DepositOpType,
+		From:                  fromOp.Account.Address,
+		ValidatorPubkey:       validatorOp.Account.Address,
+		WithdrawalCredentials: opMetadata.WithdrawalCredentials,
+		Amount:                gwei,
+		Signature:             opMetadata.Signature,
+	}
+	if err := tx.validate(); err != nil {
+		return nil, err
+	}
+
+	tx.From = common.HexToAddress(tx.From).Hex()
+	return tx, nil
+}
+
+// decodeDeposit decodes the unsigned or
+// signed transaction of a deposit.
+func decodeDeposit(transaction string, signed bool) (*deposit, *types.Error) {
+	var tx deposit
+	if err := json.Unmarshal([]byte(transaction), &tx); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if tx.Type != ethereum.DepositOpType {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			fmt.Errorf("%q is not a deposit", tx.Type),
+		)
+	}
+
+	if tx.Amount < ethereum.MinDepositAmount {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			fmt.Errorf("%w: %d Gwei", ethereum.ErrDepositAmountInvalid, tx.Amount),
+		)
+	}
+
+	if err := tx.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.signingRoot(nil); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	isSigned := len(tx.Signature) > 0
+	if tx.funding() {
+		if _, _, err := tx.transaction(); err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+
+		isSigned = len(tx.Transaction) > 0
+	}
+
+	if signed != isSigned {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			fmt.Errorf("transaction signed is %t but expected %t", isSigned, signed),
+		)
+	}
+
+	return &tx, nil
+}
+
+// validate checks the accounts, withdrawal
+// credentials and signature of the deposit.
+func (d *deposit) validate() *types.Error {
+	if !common.IsHexAddress(d.From) {
+		return wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", d.From))
+	}
+
+	pubkey, err := ethereum.ParseValidatorAddress(d.ValidatorPubkey)
+	if err != nil {
+		return wrapErr(ErrInvalidAddress, err)
+	}
+
+	// Deposits for invalid public keys
+	// can never activate a validator.
+	if err := ethereum.ValidatePubkey(pubkey); err != nil {
+		return wrapErr(ErrInvalidAddress, err)
+	}
+
+	if _, err := ethereum.ParseWithdrawalCredentialsAddress(d.WithdrawalCredentials); err != nil {
+		return wrapErr(ErrUnclearIntent, fmt.Errorf("%w: invalid withdrawal credentials", err))
+	}
+
+	if len(d.Signature) > 0 {
+		var signature [ethereum.SignatureLength]byte
+		if err := decodeFixedHex(d.Signature, signature[:]); err != nil {
+			return wrapErr(ErrSignatureInvalid, err)
+		}
+	}
+
+	return nil
+}
+
+// funding returns true if the deposit is in
+// the round of the funding transaction.
+func (d *deposit) funding() bool {
+	return len(d.DepositContract) > 0
+}
+
+// data returns the deposit data, with
+// its signature once populated.
+func (d *deposit) data() *pb.Deposit_Data {
+	pubkey, _ := ethereum.ParseValidatorAddress(d.ValidatorPubkey)
+	creds, _ := ethereum.ParseWithdrawalCredentialsAddress(d.WithdrawalCredentials)
+	signature, _ := hex.DecodeString(strings.TrimPrefix(d.Signature, "0x"))
+
+	return &pb.Deposit_Data{
+		PublicKey:             pubkey,
+		WithdrawalCredentials: creds,
+		Amount:                d.Amount,
+		Signature:             signature,
+	}
+}
+
+// signingRoot returns the root signed by the validator
+// under the DOMAIN_DEPOSIT domain. The domain is computed
+// from the fork schedule of network when it is known, in
+// which case the fork of the transaction must match it.
+func (d *deposit) signingRoot(network *ethereum.NetworkConfig) ([32]byte, error) {
+	var forkVersion [ethereum.ForkVersionLength]byte
+	if err := decodeFixedHex(d.ForkVersion, forkVersion[:]); err != nil {
+		return [32]byte{}, fmt.Errorf("%w: invalid fork version", err)
+	}
+
+	domain := ethereum.DepositDomain(forkVersion)
+	if network != nil {
+		networkDomain, err := network.Domain(ethereum.DomainDeposit, 0)
+		if err == nil && networkDomain != domain {
+			return [32]byte{}, fmt.Errorf(
+				"fork version %s is not the genesis fork version of %s",
+				d.ForkVersion,
+				network.Name,
+			)
+		}
+	}
+
+	data := d.data()
+	messageRoot := ethereum.DepositMessageRoot(data.PublicKey, data.WithdrawalCredentials, data.Amount)
+	return ethereum.ComputeSigningRoot(messageRoot, domain), nil
+}
+
+// transaction returns the unsigned transaction
+// funding the deposit and its signer.
+func (d *deposit) transaction() (*gethTypes.Transaction, gethTypes.Signer, error) {
+	if !common.IsHexAddress(d.DepositContract) {
+		return nil, nil, fmt.Errorf("%s is not a valid deposit contract", d.DepositContract)
+	}
+
+	if d.Nonce == nil {
+		return nil, nil, errors.New("nonce is missing")
+	}
+
+	gasPrice, ok := new(big.Int).SetString(d.GasPrice, 10)
+	if !ok || gasPrice.Sign() < 0 {
+		return nil, nil, fmt.Errorf("%q is not a valid gas price", d.GasPrice)
+	}
+
+	chainID, ok := new(big.Int).SetString(d.ChainID, 10)
+	if !ok || chainID.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("%q is not a valid chain id", d.ChainID)
+	}
+
+	data := d.data()
+	if len(data.Signature) != ethereum.SignatureLength {
+		return nil, nil, errors.New("deposit data is not signed")
+	}
+
+	input, err := ethereum.DepositInput(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: could not encode deposit", err)
+	}
+
+	tx := gethTypes.NewTransaction(
+		*d.Nonce,
+		common.HexToAddress(d.DepositContract),
+		ethereum.Wei(d.Amount),
+		ethereum.DepositGasLimit,
+		gasPrice,
+		input,
+	)
+
+	return tx, gethTypes.NewEIP155Signer(chainID), nil
+}
+
+// signedTransaction returns the signed transaction funding
+// the deposit, which must be the one the deposit describes.
+func (d *deposit) signedTransaction() (*gethTypes.Transaction, *types.Error) {
+	if !d.funding() {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			errors.New("deposit data must be funded: construct the funding transaction with its signature"),
+		)
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(d.Transaction, "0x"))
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	var signedTx gethTypes.Transaction
+	if err := rlp.DecodeBytes(raw, &signedTx); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	unsignedTx, signer, err := d.transaction()
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if !bytes.Equal(signer.Hash(&signedTx).Bytes(), signer.Hash(unsignedTx).Bytes()) {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			errors.New("signed transaction does not fund the deposit"),
+		)
+	}
+
+	sender, err := gethTypes.Sender(signer, &signedTx)
+	if err != nil || sender != common.HexToAddress(d.From) {
+		return nil, wrapErr(ErrSignatureInvalid, fmt.Errorf("transaction is not signed by %s", d.From))
+	}
+
+	return &signedTx, nil
+}
@@ -22,6 +22,13 @@ var (
 		ErrBlockOrphaned,
 		ErrInvalidAddress,
 		ErrBeaconNotReady,
+		ErrNetworkNotSupported,
+		ErrSubAccountInvalid,
+		ErrValidatorAlreadyExiting,
+		ErrExitTooEarly,
+		ErrValidatorNotActive,
+		ErrWeb3Provider,
+		ErrTransactionNotFound,
 	}
 
 	// ErrUnimplemented is returned when an endpoint
@@ -70,7 +77,7 @@ var (
 	}
 
 	// ErrSignatureInvalid is returned when a signature
-	// cannot be parsed.
+	// cannot be parsed or does not verify.
 	ErrSignatureInvalid = &types.Error{
 		Code:    6, //nolint
 		Message: "Signature invalid",
@@ -130,6 +137,59 @@ var (
 		Message:   "Beacon not ready",
 		Retriable: true,
 	}
+
+	// ErrNetworkNotSupported is returned when a request
+	// targets a network that is not served.
+	ErrNetworkNotSupported = &types.Error{
+		Code:    14, //nolint
+		Message: "Network not supported",
+	}
+
+	// ErrSubAccountInvalid is returned when a
+	// sub-account is not supported.
+	ErrSubAccountInvalid = &types.Error{
+		Code:    15, //nolint
+		Message: "Sub-account invalid",
+	}
+
+	// ErrValidatorAlreadyExiting is returned when a voluntary
+	// exit is submitted for a validator that is already exiting.
+	ErrValidatorAlreadyExiting = &types.Error{
+		Code:    16, //nolint
+		Message: "Validator already exiting",
+	}
+
+	// ErrExitTooEarly is returned when a voluntary exit is
+	// submitted before its epoch or before the validator
+	// has been active long enough to exit.
+	ErrExitTooEarly = &types.Error{
+		Code:      17, //nolint
+		Message:   "Voluntary exit too early",
+		Retriable: true,
+	}
+
+	// ErrValidatorNotActive is returned when a voluntary exit
+	// is submitted for a validator that is not active.
+	ErrValidatorNotActive = &types.Error{
+		Code:    18, //nolint
+		Message: "Validator not active",
+	}
+
+	// ErrWeb3Provider is returned when the execution layer
+	// cannot be queried, either because the network has
+	// no web3 provider or because it errors.
+	ErrWeb3Provider = &types.Error{
+		Code:    19, //nolint
+		Message: "web3 provider error",
+	}
+
+	// ErrTransactionNotFound is returned when a transaction
+	// is not in the mempool, either because it was included
+	// in a block or because the beacon node evicted it.
+	ErrTransactionNotFound = &types.Error{
+		Code:    20, //nolint
+		Message: "Transaction not in mempool",
+	}
 )
 
 // wrapErr adds details to the types.Error provided. We use a function
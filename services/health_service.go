@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+)
+
+const (
+	// readinessTimeout is the maximum duration a
+	// readiness check may spend querying the beacon node.
+	readinessTimeout = 5 * time.Second
+
+	// HealthStatusOK is reported when a probe succeeds.
+	HealthStatusOK = "ok"
+
+	// HealthStatusUnavailable is reported when a probe fails.
+	HealthStatusUnavailable = "unavailable"
+
+	// metricsContentType is the content type of
+	// the Prometheus text exposition format.
+	metricsContentType = "text/plain; version=0.0.4"
+)
+
+var (
+	// ErrGenesisNotLoaded is returned by a readiness check
+	// when the beacon node has not reported its genesis.
+	ErrGenesisNotLoaded = errors.New("genesis not loaded")
+
+	// ErrNotSynced is returned by a readiness check when
+	// the beacon node lags too far behind the current slot.
+	ErrNotSynced = errors.New("beacon node not synced")
+
+	// ErrNotEnoughPeers is returned by a readiness check
+	// when the beacon node has too few peers.
+	ErrNotEnoughPeers = errors.New("not enough peers")
+
+	// ErrPrysmNotRunning is returned by a readiness check
+	// when the supervised prysm process is not running.
+	ErrPrysmNotRunning = errors.New("prysm not running")
+
+	// ErrPrysmDatabase is returned by a readiness check when
+	// prysm has logged a fatal database error.
+	ErrPrysmDatabase = errors.New("prysm database error")
+
+	// ErrChainNotStarted is returned by a readiness check when
+	// the supervised prysm has not initialized the beacon state.
+	ErrChainNotStarted = errors.New("chain not started")
+)
+
+// HealthResponse is returned by the /healthz
+// and /readyz endpoints.
+type HealthResponse struct {
+	Status string                `json:"status"`
+	Reason string                `json:"reason,omitempty"`
+	Prysm  *ethereum.PrysmStatus `json:"prysm,omitempty"`
+}
+
+// HealthAPIService serves the liveness and readiness
+// probes used by orchestration.
+type HealthAPIService struct {
+	config     *configuration.Configuration
+	clients    Clients
+	supervisor ProcessSupervisor
+}
+
+// NewHealthAPIService creates a new instance of a HealthAPIService.
+// supervisor may be nil when prysm is not managed by this process.
+func NewHealthAPIService(
+	cfg *configuration.Configuration,
+	clients Clients,
+	supervisor ProcessSupervisor,
+) *HealthAPIService {
+	return &HealthAPIService{
+		config:     cfg,
+		clients:    clients,
+		supervisor: supervisor,
+	}
+}
+
+// Healthz implements the /healthz endpoint. It only reports
+// that the process is alive and never queries the beacon node.
+// Restart counts and the last exit status of a supervised prysm
+// are included in the response.
+func (s *HealthAPIService) Healthz(w http.ResponseWriter, r *http.Request) {
+	server.EncodeJSONResponse(&HealthResponse{
+		Status: HealthStatusOK,
+		Prysm:  s.prysmStatus(),
+	}, http.StatusOK, w)
+}
+
+// Readyz implements the /readyz endpoint.
+func (s *HealthAPIService) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := s.Ready(ctx); err != nil {
+		server.EncodeJSONResponse(&HealthResponse{
+			Status: HealthStatusUnavailable,
+			Reason: err.Error(),
+			Prysm:  s.prysmStatus(),
+		}, http.StatusServiceUnavailable, w)
+		return
+	}
+
+	server.EncodeJSONResponse(&HealthResponse{
+		Status: HealthStatusOK,
+		Prysm:  s.prysmStatus(),
+	}, http.StatusOK, w)
+}
+
+// Metrics implements the /metrics endpoint, exposing the status
+// of a supervised prysm in the Prometheus text format. Nothing
+// is exposed when prysm is not managed by this process.
+func (s *HealthAPIService) Metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	w.WriteHeader(http.StatusOK)
+
+	status := s.prysmStatus()
+	if status == nil {
+		return
+	}
+
+	metrics := []struct {
+		name  string
+		kind  string
+		help  string
+		value int64
+	}{
+		{"prysm_running", "gauge", "Whether prysm is running.", boolMetric(status.Running)},
+		{"prysm_restarts_total", "counter", "Restarts of prysm after an unexpected exit.", int64(status.Restarts)},
+		{"prysm_peers", "gauge", "Active peers of prysm.", int64(status.Peers)},
+		{"prysm_sync_slot", "gauge", "Last slot synced by prysm.", status.SyncSlot},
+		{"prysm_chain_started", "gauge", "Whether prysm has initialized the beacon state.", boolMetric(status.ChainStarted)},
+		{"prysm_database_error", "gauge", "Whether prysm has logged a fatal database error.", boolMetric(len(status.DatabaseError) > 0)},
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(w, "# TYPE %s %s\n", metric.name, metric.kind)
+		fmt.Fprintf(w, "%s %d\n", metric.name, metric.value)
+	}
+}
+
+func boolMetric(value bool) int64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+func (s *HealthAPIService) prysmStatus() *ethereum.PrysmStatus {
+	if s.supervisor == nil {
+		return nil
+	}
+
+	return s.supervisor.Status()
+}
+
+// Ready returns an error describing why the implementation
+// cannot serve requests yet. The implementation is only ready
+// once the beacon node of every served network is. In offline
+// mode the beacon nodes are never queried and the
+// implementation is always ready.
+func (s *HealthAPIService) Ready(ctx context.Context) error {
+	if s.config.Mode != configuration.Online {
+		return nil
+	}
+
+	// A fatal database error makes prysm exit, so it is
+	// reported before prysm is found not to be running.
+	if status := s.prysmStatus(); status != nil {
+		if len(status.DatabaseError) > 0 {
+			return fmt.Errorf("%w: %s", ErrPrysmDatabase, status.DatabaseError)
+		}
+
+		if !status.Running {
+			return ErrPrysmNotRunning
+		}
+
+		if !status.ChainStarted {
+			return ErrChainNotStarted
+		}
+	}
+
+	for _, network := range s.config.Networks {
+		client, rErr := s.clients.Client(network.Identifier)
+		if rErr != nil {
+			return fmt.Errorf("%s: network %s", rErr.Message, network.Identifier.Network)
+		}
+
+		if err := s.beaconReady(ctx, client); err != nil {
+			return fmt.Errorf("%w: network %s", err, network.Identifier.Network)
+		}
+	}
+
+	return nil
+}
+
+// beaconReady returns an error describing why the
+// beacon node behind client cannot serve requests yet.
+func (s *HealthAPIService) beaconReady(ctx context.Context, client Client) error {
+	_, genesisBlock, currentTime, syncStatus, peers, err := client.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: beacon node unreachable", err)
+	}
+
+	if genesisBlock == nil || currentTime < asserter.MinUnixEpoch {
+		return ErrGenesisNotLoaded
+	}
+
+	readiness := s.config.Readiness
+	if readiness == nil {
+		return nil
+	}
+
+	if syncStatus != nil && syncStatus.CurrentIndex != nil && syncStatus.TargetIndex != nil {
+		lag := *syncStatus.TargetIndex - *syncStatus.CurrentIndex
+		if lag > readiness.MaxSlotLag {
+			return fmt.Errorf("%w: %d slots behind", ErrNotSynced, lag)
+		}
+	}
+
+	if len(peers) < readiness.MinPeers {
+		return fmt.Errorf("%w: %d of %d", ErrNotEnoughPeers, len(peers), readiness.MinPeers)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+	mocks "rosetta-ethereum-2.0/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthEndpoints_Offline(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Offline,
+		Networks: networks,
+	}
+	mockClient := &mocks.Client{}
+	router := NewHealthRouter(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil)
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp HealthResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, HealthStatusOK, resp.Status)
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHealthEndpoints_Online(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+		Readiness: &configuration.Readiness{
+			MaxSlotLag: 10,
+			MinPeers:   1,
+		},
+	}
+
+	genesisBlock := &types.BlockIdentifier{Index: 0, Hash: "genesis"}
+	currentTime := int64(1000000000000)
+	peers := []*types.Peer{{PeerID: "peer"}}
+	syncStatus := func(current int64, target int64) *types.SyncStatus {
+		return &types.SyncStatus{
+			CurrentIndex: types.Int64(current),
+			TargetIndex:  types.Int64(target),
+		}
+	}
+
+	tests := map[string]struct {
+		genesis    *types.BlockIdentifier
+		syncStatus *types.SyncStatus
+		peers      []*types.Peer
+		err        error
+
+		expectedCode  int
+		expectedError error
+	}{
+		"ready": {
+			genesis:      genesisBlock,
+			syncStatus:   syncStatus(95, 100),
+			peers:        peers,
+			expectedCode: http.StatusOK,
+		},
+		"beacon unreachable": {
+			err:          errors.New("connection refused"),
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		"genesis not loaded": {
+			syncStatus:    syncStatus(100, 100),
+			peers:         peers,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: ErrGenesisNotLoaded,
+		},
+		"not synced": {
+			genesis:       genesisBlock,
+			syncStatus:    syncStatus(50, 100),
+			peers:         peers,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: ErrNotSynced,
+		},
+		"not enough peers": {
+			genesis:       genesisBlock,
+			syncStatus:    syncStatus(100, 100),
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedError: ErrNotEnoughPeers,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &mocks.Client{}
+			servicer := NewHealthAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil)
+			mockClient.On("Status", mock.Anything).Return(
+				nil,
+				test.genesis,
+				currentTime,
+				test.syncStatus,
+				test.peers,
+				test.err,
+			).Once()
+
+			err := servicer.Ready(context.Background())
+			switch {
+			case test.expectedError != nil:
+				assert.True(t, errors.Is(err, test.expectedError))
+			case test.err != nil:
+				assert.True(t, errors.Is(err, test.err))
+			default:
+				assert.NoError(t, err)
+			}
+
+			mockClient.On("Status", mock.Anything).Return(
+				nil,
+				test.genesis,
+				currentTime,
+				test.syncStatus,
+				test.peers,
+				test.err,
+			).Once()
+			rec := httptest.NewRecorder()
+			NewHealthRouter(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil).ServeHTTP(
+				rec,
+				httptest.NewRequest(http.MethodGet, "/readyz", nil),
+			)
+			assert.Equal(t, test.expectedCode, rec.Code)
+
+			rec = httptest.NewRecorder()
+			NewHealthRouter(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil).ServeHTTP(
+				rec,
+				httptest.NewRequest(http.MethodGet, "/healthz", nil),
+			)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+type fakeSupervisor struct {
+	status *ethereum.PrysmStatus
+}
+
+func (f *fakeSupervisor) Status() *ethereum.PrysmStatus {
+	return f.status
+}
+
+func TestHealthEndpoints_Supervisor(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:      configuration.Online,
+		Networks:  networks,
+		Readiness: &configuration.Readiness{},
+	}
+	mockClient := &mocks.Client{}
+	supervisor := &fakeSupervisor{
+		status: &ethereum.PrysmStatus{
+			Running:        false,
+			Restarts:       2,
+			LastExitStatus: "exit status 1",
+		},
+	}
+	router := NewHealthRouter(cfg, Clients{ethereum.MainnetNetwork: mockClient}, supervisor)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp HealthResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, supervisor.status, resp.Prysm)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, ErrPrysmNotRunning.Error(), resp.Reason)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHealthEndpoints_PrysmDatabaseError(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:      configuration.Online,
+		Networks:  networks,
+		Readiness: &configuration.Readiness{},
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewHealthAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient}, &fakeSupervisor{
+		status: &ethereum.PrysmStatus{
+			Running:       false,
+			Restarts:      1,
+			DatabaseError: "Could not open database",
+		},
+	})
+
+	err := servicer.Ready(context.Background())
+	assert.True(t, errors.Is(err, ErrPrysmDatabase))
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHealthEndpoints_ChainNotStarted(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:      configuration.Online,
+		Networks:  networks,
+		Readiness: &configuration.Readiness{},
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewHealthAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient}, &fakeSupervisor{
+		status: &ethereum.PrysmStatus{Running: true, Peers: 4},
+	})
+
+	err := servicer.Ready(context.Background())
+	assert.True(t, errors.Is(err, ErrChainNotStarted))
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHealthEndpoints_Metrics(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+	}
+	supervisor := &fakeSupervisor{
+		status: &ethereum.PrysmStatus{
+			Running:      true,
+			Restarts:     2,
+			Peers:        30,
+			SyncSlot:     2049,
+			ChainStarted: true,
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	NewHealthRouter(cfg, Clients{}, supervisor).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, metricsContentType, rec.Header().Get("Content-Type"))
+	for _, line := range []string{
+		"# TYPE prysm_running gauge\nprysm_running 1\n",
+		"# TYPE prysm_restarts_total counter\nprysm_restarts_total 2\n",
+		"prysm_peers 30\n",
+		"prysm_sync_slot 2049\n",
+		"prysm_chain_started 1\n",
+		"prysm_database_error 0\n",
+	} {
+		assert.Contains(t, rec.Body.String(), line)
+	}
+
+	rec = httptest.NewRecorder()
+	NewHealthRouter(cfg, Clients{}, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -2,18 +2,33 @@ package services
 
 import (
 	"context"
+	"errors"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
 
-	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
 // MempoolAPIService implements the server.MempoolAPIServicer interface.
+//
+// The mempool of the beacon chain is made of the operation
+// pools of the beacon node: attestations, voluntary exits
+// and slashings waiting to be included in a block.
 type MempoolAPIService struct {
+	config  *configuration.Configuration
+	clients Clients
 }
 
 // NewMempoolAPIService creates a new instance of a MempoolAPIService.
-func NewMempoolAPIService() server.MempoolAPIServicer {
-	return &MempoolAPIService{}
+func NewMempoolAPIService(
+	cfg *configuration.Configuration,
+	clients Clients,
+) *MempoolAPIService {
+	return &MempoolAPIService{
+		config:  cfg,
+		clients: clients,
+	}
 }
 
 // Mempool implements the /mempool endpoint.
@@ -21,7 +36,23 @@ func (s *MempoolAPIService) Mempool(
 	ctx context.Context,
 	request *types.NetworkRequest,
 ) (*types.MempoolResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	identifiers, err := client.Mempool(ctx)
+	if err != nil {
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	return &types.MempoolResponse{
+		TransactionIdentifiers: identifiers,
+	}, nil
 }
 
 // MempoolTransaction implements the /mempool/transaction endpoint.
@@ -29,5 +60,24 @@ func (s *MempoolAPIService) MempoolTransaction(
 	ctx context.Context,
 	request *types.MempoolTransactionRequest,
 ) (*types.MempoolTransactionResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	transaction, err := client.MempoolTransaction(ctx, request.TransactionIdentifier)
+	if errors.Is(err, ethereum.ErrTransactionNotFound) {
+		return nil, wrapErr(ErrTransactionNotFound, err)
+	}
+	if err != nil {
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	return &types.MempoolTransactionResponse{
+		Transaction: transaction,
+	}, nil
 }
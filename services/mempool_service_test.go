@@ -2,22 +2,130 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+	mocks "rosetta-ethereum-2.0/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestMempoolEndpoints(t *testing.T) {
-	servicer := NewMempoolAPIService()
+func TestMempoolService_Offline(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Offline,
+		Networks: networks,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewMempoolAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
 	ctx := context.Background()
 
-	mem, err := servicer.Mempool(ctx, nil)
+	mem, err := servicer.Mempool(ctx, &types.NetworkRequest{NetworkIdentifier: networkIdentifier})
 	assert.Nil(t, mem)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
 
-	memTransaction, err := servicer.MempoolTransaction(ctx, nil)
+	memTransaction, err := servicer.MempoolTransaction(ctx, &types.MempoolTransactionRequest{
+		NetworkIdentifier:     networkIdentifier,
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: "f5a5fd42"},
+	})
 	assert.Nil(t, memTransaction)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestMempoolService_Online(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewMempoolAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient})
+	ctx := context.Background()
+
+	t.Run("pending operations", func(t *testing.T) {
+		identifiers := []*types.TransactionIdentifier{
+			{Hash: "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b"},
+			{Hash: "9c3e2e9c1f4a8f1d7a4e6c3b2a1d0e9f8c7b6a5d4e3f2a1b0c9d8e7f6a5b4c3d"},
+		}
+		mockClient.On("Mempool", ctx).Return(identifiers, nil).Once()
+
+		mem, err := servicer.Mempool(ctx, &types.NetworkRequest{NetworkIdentifier: networkIdentifier})
+		assert.Nil(t, err)
+		assert.Equal(t, &types.MempoolResponse{TransactionIdentifiers: identifiers}, mem)
+	})
+
+	t.Run("beacon node error", func(t *testing.T) {
+		mockClient.On("Mempool", ctx).Return(nil, errors.New("connection refused")).Once()
+
+		mem, err := servicer.Mempool(ctx, &types.NetworkRequest{NetworkIdentifier: networkIdentifier})
+		assert.Nil(t, mem)
+		assert.Equal(t, ErrBeacon.Code, err.Code)
+	})
+
+	t.Run("pending operation", func(t *testing.T) {
+		identifier := &types.TransactionIdentifier{
+			Hash: "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b",
+		}
+		transaction := &types.Transaction{
+			TransactionIdentifier: identifier,
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                ethereum.VoluntaryExitOpType,
+					Status:              types.String(ethereum.SuccessStatus),
+					Account:             &types.AccountIdentifier{Address: "0x" + g1Generator},
+				},
+			},
+		}
+		mockClient.On("MempoolTransaction", ctx, identifier).Return(transaction, nil).Once()
+
+		memTransaction, err := servicer.MempoolTransaction(ctx, &types.MempoolTransactionRequest{
+			NetworkIdentifier:     networkIdentifier,
+			TransactionIdentifier: identifier,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &types.MempoolTransactionResponse{Transaction: transaction}, memTransaction)
+	})
+
+	t.Run("included or evicted operation", func(t *testing.T) {
+		identifier := &types.TransactionIdentifier{Hash: "included"}
+		mockClient.On("MempoolTransaction", ctx, identifier).
+			Return(nil, fmt.Errorf("%w: included is not pending", ethereum.ErrTransactionNotFound)).Once()
+
+		memTransaction, err := servicer.MempoolTransaction(ctx, &types.MempoolTransactionRequest{
+			NetworkIdentifier:     networkIdentifier,
+			TransactionIdentifier: identifier,
+		})
+		assert.Nil(t, memTransaction)
+		assert.Equal(t, ErrTransactionNotFound.Code, err.Code)
+		assert.NotEmpty(t, err.Details["context"])
+	})
+
+	t.Run("transaction lookup error", func(t *testing.T) {
+		identifier := &types.TransactionIdentifier{Hash: "unreachable"}
+		mockClient.On("MempoolTransaction", ctx, identifier).Return(nil, errors.New("connection refused")).Once()
+
+		memTransaction, err := servicer.MempoolTransaction(ctx, &types.MempoolTransactionRequest{
+			NetworkIdentifier:     networkIdentifier,
+			TransactionIdentifier: identifier,
+		})
+		assert.Nil(t, memTransaction)
+		assert.Equal(t, ErrBeacon.Code, err.Code)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		mem, err := servicer.Mempool(ctx, &types.NetworkRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{Blockchain: ethereum.Blockchain, Network: "Medalla"},
+		})
+		assert.Nil(t, mem)
+		assert.Equal(t, ErrNetworkNotSupported.Code, err.Code)
+	})
+
+	mockClient.AssertExpectations(t)
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"rosetta-ethereum-2.0/configuration"
 	"rosetta-ethereum-2.0/ethereum"
@@ -12,18 +13,22 @@ import (
 
 // NetworkAPIService implements the server.NetworkAPIServicer interface.
 type NetworkAPIService struct {
-	config *configuration.Configuration
-	client Client
+	config     *configuration.Configuration
+	clients    Clients
+	supervisor ProcessSupervisor
 }
 
 // NewNetworkAPIService creates a new instance of a NetworkAPIService.
+// supervisor may be nil when prysm is not managed by this process.
 func NewNetworkAPIService(
 	cfg *configuration.Configuration,
-	client Client,
+	clients Clients,
+	supervisor ProcessSupervisor,
 ) *NetworkAPIService {
 	return &NetworkAPIService{
-		config: cfg,
-		client: client,
+		config:     cfg,
+		clients:    clients,
+		supervisor: supervisor,
 	}
 }
 
@@ -33,11 +38,13 @@ func (s *NetworkAPIService) NetworkList(
 	request *types.MetadataRequest,
 ) (*types.NetworkListResponse, *types.Error) {
 	return &types.NetworkListResponse{
-		NetworkIdentifiers: []*types.NetworkIdentifier{s.config.Network},
+		NetworkIdentifiers: s.config.NetworkIdentifiers(),
 	}, nil
 }
 
 // NetworkOptions implements the /network/options endpoint.
+// Allow only lists the names of /call methods, so their
+// parameters are documented in the version metadata.
 func (s *NetworkAPIService) NetworkOptions(
 	ctx context.Context,
 	request *types.NetworkRequest,
@@ -47,6 +54,9 @@ func (s *NetworkAPIService) NetworkOptions(
 			NodeVersion:       ethereum.NodeVersion,
 			RosettaVersion:    types.RosettaAPIVersion,
 			MiddlewareVersion: types.String(configuration.MiddlewareVersion),
+			Metadata: map[string]interface{}{
+				"call_methods": ethereum.CallMethodSpecs,
+			},
 		},
 		Allow: &types.Allow{
 			Errors:                  Errors,
@@ -67,12 +77,17 @@ func (s *NetworkAPIService) NetworkStatus(
 		return nil, ErrUnavailableOffline
 	}
 
-	currentBlock, genesisBlock, currentTime, syncStatus, peers, err := s.client.Status(ctx)
+	client, rErr := s.clients.Client(request.NetworkIdentifier)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	currentBlock, genesisBlock, currentTime, syncStatus, peers, err := client.Status(ctx)
 	if err != nil {
 		return nil, wrapErr(ErrBeacon, err)
 	}
 
-	if currentTime < asserter.MinUnixEpoch {
+	if genesisBlock == nil || currentTime < asserter.MinUnixEpoch {
 		return nil, ErrBeaconNotReady
 	}
 
@@ -80,7 +95,38 @@ func (s *NetworkAPIService) NetworkStatus(
 		CurrentBlockIdentifier: currentBlock,
 		CurrentBlockTimestamp:  currentTime,
 		GenesisBlockIdentifier: genesisBlock,
-		SyncStatus:             syncStatus,
+		SyncStatus:             s.prysmSyncStatus(request.NetworkIdentifier, syncStatus),
 		Peers:                  peers,
 	}, nil
 }
+
+// prysmSyncStatus appends the restart count and last exit
+// status of a supervised prysm to the sync stage of the
+// network it serves. NetworkStatusResponse has no metadata,
+// so the stage is the only field left to report them in.
+func (s *NetworkAPIService) prysmSyncStatus(
+	network *types.NetworkIdentifier,
+	syncStatus *types.SyncStatus,
+) *types.SyncStatus {
+	local := s.config.LocalNetwork()
+	if s.supervisor == nil || syncStatus == nil || local == nil || local.Identifier.Network != network.Network {
+		return syncStatus
+	}
+
+	status := s.supervisor.Status()
+	if status == nil {
+		return syncStatus
+	}
+
+	stage := fmt.Sprintf("prysm_restarts=%d", status.Restarts)
+	if len(status.LastExitStatus) > 0 {
+		stage = fmt.Sprintf("%s prysm_last_exit_status=%q", stage, status.LastExitStatus)
+	}
+	if syncStatus.Stage != nil {
+		stage = fmt.Sprintf("%s %s", *syncStatus.Stage, stage)
+	}
+
+	annotated := *syncStatus
+	annotated.Stage = &stage
+	return &annotated
+}
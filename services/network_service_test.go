@@ -19,6 +19,9 @@ var (
 			RosettaVersion:    types.RosettaAPIVersion,
 			NodeVersion:       "1.0.5",
 			MiddlewareVersion: &middlewareVersion,
+			Metadata: map[string]interface{}{
+				"call_methods": ethereum.CallMethodSpecs,
+			},
 		},
 		Allow: &types.Allow{
 			OperationStatuses:       ethereum.OperationStatuses,
@@ -33,15 +36,19 @@ var (
 		Network:    ethereum.MainnetNetwork,
 		Blockchain: ethereum.Blockchain,
 	}
+
+	networks = []*configuration.Network{
+		{Identifier: networkIdentifier},
+	}
 )
 
 func TestNetworkEndpoints_Offline(t *testing.T) {
 	cfg := &configuration.Configuration{
-		Mode:    configuration.Offline,
-		Network: networkIdentifier,
+		Mode:     configuration.Offline,
+		Networks: networks,
 	}
 	mockClient := &mocks.Client{}
-	servicer := NewNetworkAPIService(cfg, mockClient)
+	servicer := NewNetworkAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil)
 	ctx := context.Background()
 
 	networkList, err := servicer.NetworkList(ctx, nil)
@@ -50,7 +57,7 @@ func TestNetworkEndpoints_Offline(t *testing.T) {
 		networkIdentifier,
 	}, networkList.NetworkIdentifiers)
 
-	networkStatus, err := servicer.NetworkStatus(ctx, nil)
+	networkStatus, err := servicer.NetworkStatus(ctx, &types.NetworkRequest{NetworkIdentifier: networkIdentifier})
 	assert.Nil(t, networkStatus)
 	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
 	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
@@ -64,12 +71,11 @@ func TestNetworkEndpoints_Offline(t *testing.T) {
 
 func TestNetworkEndpoints_Online(t *testing.T) {
 	cfg := &configuration.Configuration{
-		Mode:                   configuration.Online,
-		Network:                networkIdentifier,
-		GenesisBlockIdentifier: ethereum.MainnetGenesisBlockIdentifier,
+		Mode:     configuration.Online,
+		Networks: networks,
 	}
 	mockClient := &mocks.Client{}
-	servicer := NewNetworkAPIService(cfg, mockClient)
+	servicer := NewNetworkAPIService(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil)
 	ctx := context.Background()
 
 	networkList, err := servicer.NetworkList(ctx, nil)
@@ -100,15 +106,16 @@ func TestNetworkEndpoints_Online(t *testing.T) {
 		ctx,
 	).Return(
 		currentBlock,
+		ethereum.MainnetGenesisBlockIdentifier,
 		currentTime,
 		syncStatus,
 		peers,
 		nil,
 	)
-	networkStatus, err := servicer.NetworkStatus(ctx, nil)
+	networkStatus, err := servicer.NetworkStatus(ctx, &types.NetworkRequest{NetworkIdentifier: networkIdentifier})
 	assert.Nil(t, err)
 	assert.Equal(t, &types.NetworkStatusResponse{
-		GenesisBlockIdentifier: nil,
+		GenesisBlockIdentifier: ethereum.MainnetGenesisBlockIdentifier,
 		CurrentBlockIdentifier: currentBlock,
 		CurrentBlockTimestamp:  currentTime,
 		Peers:                  peers,
@@ -121,3 +128,125 @@ func TestNetworkEndpoints_Online(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestNetworkEndpoints_Supervisor(t *testing.T) {
+	remoteIdentifier := &types.NetworkIdentifier{
+		Network:    ethereum.TestnetNetwork,
+		Blockchain: ethereum.Blockchain,
+	}
+	cfg := &configuration.Configuration{
+		Mode: configuration.Online,
+		Networks: []*configuration.Network{
+			{Identifier: networkIdentifier},
+			{Identifier: remoteIdentifier, RemoteBeacon: true},
+		},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		network *types.NetworkIdentifier
+		status  *ethereum.PrysmStatus
+
+		expectedStage string
+	}{
+		"restarted prysm": {
+			network:       networkIdentifier,
+			status:        &ethereum.PrysmStatus{Running: true, Restarts: 2, LastExitStatus: "exit status 1"},
+			expectedStage: `synced prysm_restarts=2 prysm_last_exit_status="exit status 1"`,
+		},
+		"prysm never exited": {
+			network:       networkIdentifier,
+			status:        &ethereum.PrysmStatus{Running: true},
+			expectedStage: "synced prysm_restarts=0",
+		},
+		"remote beacon node": {
+			network:       remoteIdentifier,
+			status:        &ethereum.PrysmStatus{Running: true, Restarts: 2, LastExitStatus: "exit status 1"},
+			expectedStage: "synced",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &mocks.Client{}
+			servicer := NewNetworkAPIService(cfg, Clients{
+				ethereum.MainnetNetwork: mockClient,
+				ethereum.TestnetNetwork: mockClient,
+			}, &fakeSupervisor{status: test.status})
+
+			syncStatus := &types.SyncStatus{Stage: types.String("synced")}
+			mockClient.On("Status", ctx).Return(
+				&types.BlockIdentifier{Index: 10, Hash: "block 10"},
+				ethereum.MainnetGenesisBlockIdentifier,
+				int64(1000000000000),
+				syncStatus,
+				[]*types.Peer{},
+				nil,
+			).Once()
+
+			networkStatus, err := servicer.NetworkStatus(ctx, &types.NetworkRequest{NetworkIdentifier: test.network})
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStage, *networkStatus.SyncStatus.Stage)
+			assert.Equal(t, "synced", *syncStatus.Stage)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNetworkEndpoints_MultipleNetworks(t *testing.T) {
+	testnetIdentifier := &types.NetworkIdentifier{
+		Network:    ethereum.TestnetNetwork,
+		Blockchain: ethereum.Blockchain,
+	}
+	cfg := &configuration.Configuration{
+		Mode: configuration.Online,
+		Networks: []*configuration.Network{
+			{Identifier: networkIdentifier},
+			{Identifier: testnetIdentifier},
+		},
+	}
+	mainnetClient := &mocks.Client{}
+	testnetClient := &mocks.Client{}
+	servicer := NewNetworkAPIService(cfg, Clients{
+		ethereum.MainnetNetwork: mainnetClient,
+		ethereum.TestnetNetwork: testnetClient,
+	}, nil)
+	ctx := context.Background()
+
+	networkList, err := servicer.NetworkList(ctx, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*types.NetworkIdentifier{
+		networkIdentifier,
+		testnetIdentifier,
+	}, networkList.NetworkIdentifiers)
+
+	testnetGenesis := &types.BlockIdentifier{Index: 0, Hash: "testnet genesis"}
+	testnetClient.On(
+		"Status",
+		ctx,
+	).Return(
+		&types.BlockIdentifier{Index: 10, Hash: "block 10"},
+		testnetGenesis,
+		int64(1000000000000),
+		&types.SyncStatus{},
+		[]*types.Peer{},
+		nil,
+	)
+	networkStatus, err := servicer.NetworkStatus(ctx, &types.NetworkRequest{
+		NetworkIdentifier: testnetIdentifier,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, testnetGenesis, networkStatus.GenesisBlockIdentifier)
+
+	networkStatus, err = servicer.NetworkStatus(ctx, &types.NetworkRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{
+			Network:    "devnet",
+			Blockchain: ethereum.Blockchain,
+		},
+	})
+	assert.Nil(t, networkStatus)
+	assert.Equal(t, ErrNetworkNotSupported.Code, err.Code)
+
+	mainnetClient.AssertExpectations(t)
+	testnetClient.AssertExpectations(t)
+}
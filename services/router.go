@@ -7,50 +7,98 @@ import (
 
 	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/server"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	// tracerOperation is the name of the http
+	// server span wrapping each Rosetta request.
+	tracerOperation = "rosetta"
 )
 
 // NewBlockchainRouter creates a Mux http.Handler from a collection
-// of server controllers.
+// of server controllers. Requests are dispatched to the Client of
+// the network they identify. Every request is wrapped in a trace span
+// named after its path, which is carried through the request context
+// into the Client.
 func NewBlockchainRouter(
 	config *configuration.Configuration,
-	client Client,
+	clients Clients,
+	supervisor ProcessSupervisor,
 	asserter *asserter.Asserter,
 ) http.Handler {
-	networkAPIService := NewNetworkAPIService(config, client)
+	networkAPIService := NewNetworkAPIService(config, clients, supervisor)
 	networkAPIController := server.NewNetworkAPIController(
 		networkAPIService,
 		asserter,
 	)
 
-	accountAPIService := NewAccountAPIService()
+	accountAPIService := NewAccountAPIService(config, clients)
 	accountAPIController := server.NewAccountAPIController(
 		accountAPIService,
 		asserter,
 	)
 
-	blockAPIService := NewBlockAPIService(config, client)
+	blockAPIService := NewBlockAPIService(config, clients)
 	blockAPIController := server.NewBlockAPIController(
 		blockAPIService,
 		asserter,
 	)
 
-	constructionAPIService := NewConstructionAPIService()
+	constructionAPIService := NewConstructionAPIService(config, clients)
+	blsConstructionAPIController := NewBLSConstructionAPIController(
+		constructionAPIService,
+		asserter,
+	)
 	constructionAPIController := server.NewConstructionAPIController(
 		constructionAPIService,
 		asserter,
 	)
 
-	mempoolAPIService := NewMempoolAPIService()
+	mempoolAPIService := NewMempoolAPIService(config, clients)
 	mempoolAPIController := server.NewMempoolAPIController(
 		mempoolAPIService,
 		asserter,
 	)
 
-	return server.NewRouter(
+	callAPIService := NewCallAPIService(config, clients)
+	callAPIController := server.NewCallAPIController(
+		callAPIService,
+		asserter,
+	)
+
+	router := server.NewRouter(
 		networkAPIController,
 		accountAPIController,
 		blockAPIController,
+		blsConstructionAPIController,
 		constructionAPIController,
 		mempoolAPIController,
+		callAPIController,
 	)
+
+	return otelhttp.NewHandler(
+		router,
+		tracerOperation,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.URL.Path
+		}),
+	)
+}
+
+// NewHealthRouter creates an http.Handler serving the
+// /healthz and /readyz probes and the /metrics of prysm.
+func NewHealthRouter(
+	config *configuration.Configuration,
+	clients Clients,
+	supervisor ProcessSupervisor,
+) http.Handler {
+	healthAPIService := NewHealthAPIService(config, clients, supervisor)
+
+	router := http.NewServeMux()
+	router.HandleFunc("/healthz", healthAPIService.Healthz)
+	router.HandleFunc("/readyz", healthAPIService.Readyz)
+	router.HandleFunc("/metrics", healthAPIService.Metrics)
+
+	return router
 }
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"rosetta-ethereum-2.0/configuration"
+	"rosetta-ethereum-2.0/ethereum"
+	mocks "rosetta-ethereum-2.0/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestBlockchainRouter_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Networks: networks,
+	}
+	a, err := asserter.NewServer(
+		ethereum.OperationTypes,
+		ethereum.HistoricalBalanceSupported,
+		[]*types.NetworkIdentifier{networkIdentifier},
+		ethereum.CallMethods,
+		false,
+	)
+	assert.NoError(t, err)
+
+	mockClient := &mocks.Client{}
+	router := NewBlockchainRouter(cfg, Clients{ethereum.MainnetNetwork: mockClient}, nil, a)
+
+	block := &types.Block{
+		BlockIdentifier: &types.BlockIdentifier{
+			Index: 100,
+			Hash:  "block 100",
+		},
+		ParentBlockIdentifier: &types.BlockIdentifier{
+			Index: 99,
+			Hash:  "block 99",
+		},
+		Timestamp: 1000000000000,
+	}
+
+	var traceID trace.TraceID
+	mockClient.On(
+		"Block",
+		mock.MatchedBy(func(ctx context.Context) bool {
+			spanContext := trace.SpanContextFromContext(ctx)
+			traceID = spanContext.TraceID()
+			return spanContext.IsValid()
+		}),
+		types.ConstructPartialBlockIdentifier(block.BlockIdentifier),
+	).Return(block, nil).Once()
+
+	body, err := json.Marshal(&types.BlockRequest{
+		NetworkIdentifier: networkIdentifier,
+		BlockIdentifier:   types.ConstructPartialBlockIdentifier(block.BlockIdentifier),
+	})
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/block", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "/block", spans[0].Name())
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Equal(t, spans[0].SpanContext().TraceID(), traceID)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestBlockchainRouter_ConstructionDerive(t *testing.T) {
+	// Derive does not need a beacon node.
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Offline,
+		Networks: networks,
+	}
+	a, err := asserter.NewServer(
+		ethereum.OperationTypes,
+		ethereum.HistoricalBalanceSupported,
+		[]*types.NetworkIdentifier{networkIdentifier},
+		ethereum.CallMethods,
+		false,
+	)
+	assert.NoError(t, err)
+	router := NewBlockchainRouter(cfg, Clients{}, nil, a)
+
+	tests := map[string]struct {
+		request *types.ConstructionDeriveRequest
+
+		expectedStatus   int
+		expectedResponse interface{}
+	}{
+		"bls12381": {
+			request: &types.ConstructionDeriveRequest{
+				NetworkIdentifier: networkIdentifier,
+				PublicKey: &types.PublicKey{
+					Bytes:     mustDecodeHex(g1Generator),
+					CurveType: ethereum.BLS12381CurveType,
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: &types.ConstructionDeriveResponse{
+				AccountIdentifier: &types.AccountIdentifier{Address: "0x" + g1Generator},
+			},
+		},
+		"empty public key": {
+			request: &types.ConstructionDeriveRequest{
+				NetworkIdentifier: networkIdentifier,
+				PublicKey: &types.PublicKey{
+					CurveType: ethereum.BLS12381CurveType,
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedResponse: &types.Error{
+				Message: asserter.ErrPublicKeyBytesEmpty.Error(),
+			},
+		},
+		"unsupported network": {
+			request: &types.ConstructionDeriveRequest{
+				NetworkIdentifier: &types.NetworkIdentifier{
+					Blockchain: ethereum.Blockchain,
+					Network:    "Goerli",
+				},
+				PublicKey: &types.PublicKey{
+					Bytes:     mustDecodeHex(g1Generator),
+					CurveType: ethereum.BLS12381CurveType,
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(test.request)
+			assert.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(
+				http.MethodPost,
+				"/construction/derive",
+				bytes.NewReader(body),
+			))
+			assert.Equal(t, test.expectedStatus, rec.Code)
+
+			if test.expectedResponse != nil {
+				expected, err := json.Marshal(test.expectedResponse)
+				assert.NoError(t, err)
+				assert.JSONEq(t, string(expected), rec.Body.String())
+			}
+		})
+	}
+}
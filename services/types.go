@@ -2,8 +2,14 @@ package services
 
 import (
 	"context"
+	"fmt"
+
+	"rosetta-ethereum-2.0/ethereum"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 )
 
 // Client is used by the servicers to get block
@@ -22,4 +28,139 @@ type Client interface {
 		context.Context,
 		*types.PartialBlockIdentifier,
 	) (*types.Block, error)
+
+	Balance(
+		context.Context,
+		*types.AccountIdentifier,
+	) (*types.AccountBalanceResponse, error)
+
+	ValidatorIndex(context.Context, []byte) (uint64, error)
+
+	Fork(context.Context) (*ethereum.Fork, error)
+
+	SubmitVoluntaryExit(context.Context, *pb.SignedVoluntaryExit) ([]byte, error)
+
+	DepositContract(context.Context) (common.Address, error)
+
+	TransactionParams(context.Context, common.Address) (*ethereum.TransactionParams, error)
+
+	SendTransaction(context.Context, *gethTypes.Transaction) error
+
+	Mempool(context.Context) ([]*types.TransactionIdentifier, error)
+
+	MempoolTransaction(context.Context, *types.TransactionIdentifier) (*types.Transaction, error)
+
+	Call(context.Context, *types.CallRequest) (*types.CallResponse, error)
+}
+
+// Clients maps the Network of every served
+// *types.NetworkIdentifier to the Client backing it.
+type Clients map[string]Client
+
+// Client returns the Client serving network.
+func (c Clients) Client(network *types.NetworkIdentifier) (Client, *types.Error) {
+	if network != nil {
+		if client, ok := c[network.Network]; ok {
+			return client, nil
+		}
+	}
+
+	return nil, wrapErr(ErrNetworkNotSupported, fmt.Errorf("%+v is not served", network))
+}
+
+// ProcessSupervisor reports the state of the beacon
+// node process managed by this implementation.
+type ProcessSupervisor interface {
+	Status() *ethereum.PrysmStatus
+}
+
+// options is the output of /construction/preprocess.
+type options struct {
+	// Type is the operation type of the intent.
+	Type string `json:"type"`
+
+	ValidatorPubkey string `json:"validator_pubkey"`
+
+	// Epoch is the epoch a voluntary exit is valid from,
+	// which defaults to the epoch of the chain head.
+	Epoch *uint64 `json:"epoch,omitempty,string"`
+
+	// From is the account funding a deposit. It is only
+	// set once the deposit data is signed, as the funding
+	// transaction includes the signature.
+	From string `json:"from,omitempty"`
+}
+
+// exitMetadata is the output of /construction/metadata
+// for a voluntary exit.
+type exitMetadata struct {
+	ValidatorIndex        uint64 `json:"validator_index,string"`
+	Epoch                 uint64 `json:"epoch,string"`
+	ForkVersion           string `json:"fork_version"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+
+// voluntaryExit is the unsigned and, once Signature
+// is populated, signed transaction of a voluntary exit.
+type voluntaryExit struct {
+	Type            string `json:"type"`
+	ValidatorPubkey string `json:"validator_pubkey"`
+	exitMetadata
+	Signature string `json:"signature,omitempty"`
+}
+
+// exitOperationMetadata is the metadata of
+// a VOLUNTARY_EXIT operation.
+type exitOperationMetadata struct {
+	ValidatorIndex *uint64 `json:"validator_index,omitempty,string"`
+	Epoch          *uint64 `json:"epoch,omitempty,string"`
+}
+
+// depositMetadata is the output of /construction/metadata
+// for a deposit. The transaction parameters are only set
+// when the funding transaction is constructed.
+type depositMetadata struct {
+	// ForkVersion is the genesis fork version,
+	// which deposits are signed with.
+	ForkVersion string `json:"fork_version"`
+
+	DepositContract string  `json:"deposit_contract,omitempty"`
+	Nonce           *uint64 `json:"nonce,omitempty,string"`
+	GasPrice        string  `json:"gas_price,omitempty"`
+	ChainID         string  `json:"chain_id,omitempty"`
+}
+
+// deposit is the unsigned and signed transaction of a
+// deposit, which is signed in two rounds because the
+// transaction funding it includes the signature of the
+// deposit data:
+//
+//  1. Without a Signature, the validator signs the deposit
+//     data, which populates the Signature.
+//  2. With a Signature and a DepositContract, From signs
+//     the funding transaction, which populates Transaction
+//     with the signed transaction.
+type deposit struct {
+	Type                  string `json:"type"`
+	From                  string `json:"from"`
+	ValidatorPubkey       string `json:"validator_pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+
+	// Amount is the deposit amount in Gwei.
+	Amount uint64 `json:"amount,string"`
+
+	depositMetadata
+	Signature   string `json:"signature,omitempty"`
+	Transaction string `json:"transaction,omitempty"`
+}
+
+// depositOperationMetadata is the metadata of the
+// DEPOSIT operation crediting the validator.
+type depositOperationMetadata struct {
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+
+	// Signature is the signature of the deposit data by
+	// the validator. Intents carrying it construct the
+	// transaction funding the deposit.
+	Signature string `json:"signature,omitempty"`
 }
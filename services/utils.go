@@ -0,0 +1,107 @@
+package services
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"rosetta-ethereum-2.0/ethereum"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// *JSONMap functions are needed because `types.MarshalMap/types.UnmarshalMap`
+// does not respect custom JSON marshalers.
+
+// marshalJSONMap converts an interface into a map[string]interface{}.
+func marshalJSONMap(i interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// unmarshalJSONMap converts map[string]interface{} into a interface{}.
+func unmarshalJSONMap(m map[string]interface{}, i interface{}) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, i)
+}
+
+// blsSignature returns the bytes of the only signature in
+// signatures, which must be a BLS12-381 signature of root
+// by the validator with the account address.
+func blsSignature(signatures []*types.Signature, address string, root [32]byte) ([]byte, *types.Error) {
+	if len(signatures) != 1 {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("expected 1 signature but got %d", len(signatures)),
+		)
+	}
+	signature := signatures[0]
+
+	if signature.SignatureType != ethereum.BLS12381SignatureType {
+		return nil, signatureErr(
+			address,
+			root,
+			fmt.Errorf("%s is not a supported signature type", signature.SignatureType),
+		)
+	}
+
+	if signature.SigningPayload == nil || !bytes.Equal(signature.SigningPayload.Bytes, root[:]) {
+		return nil, signatureErr(address, root, errors.New("signature is not of the payload"))
+	}
+
+	if !strings.EqualFold(ethereum.ValidatorAddress(signature.PublicKey.Bytes), address) {
+		return nil, signatureErr(address, root, fmt.Errorf("signature is not by %s", address))
+	}
+
+	if err := ethereum.VerifySignature(signature.PublicKey.Bytes, root, signature.Bytes); err != nil {
+		return nil, signatureErr(address, root, err)
+	}
+
+	return signature.Bytes, nil
+}
+
+// signatureErr returns ErrSignatureInvalid with
+// details naming the payload whose signature failed.
+func signatureErr(address string, root [32]byte, err error) *types.Error {
+	rErr := wrapErr(ErrSignatureInvalid, err)
+	rErr.Details["account"] = address
+	rErr.Details["payload"] = encodeHex(root[:])
+	return rErr
+}
+
+// encodeHex returns the 0x-prefixed hex encoding of b.
+func encodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// decodeFixedHex decodes the 0x-prefixed hex
+// string s into b, which it must fill exactly.
+func decodeFixedHex(s string, b []byte) error {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return err
+	}
+
+	if len(decoded) != len(b) {
+		return fmt.Errorf("expected %d bytes but got %d", len(b), len(decoded))
+	}
+
+	copy(b, decoded)
+	return nil
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"rosetta-ethereum-2.0/ethereum"
+
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// voluntaryExitDescriptions match the
+// operations of a voluntary exit.
+var voluntaryExitDescriptions = &parser.Descriptions{
+	OperationDescriptions: []*parser.OperationDescription{
+		{
+			Type: ethereum.VoluntaryExitOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: false,
+			},
+		},
+	},
+	ErrUnmatched: true,
+}
+
+// preprocessVoluntaryExit returns the options
+// of a voluntary exit intent.
+func preprocessVoluntaryExit(operations []*types.Operation) (*options, *types.Error) {
+	exitOp, rErr := matchVoluntaryExit(operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var opMetadata exitOperationMetadata
+	if err := unmarshalJSONMap(exitOp.Metadata, &opMetadata); err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	return &options{
+		Type:            ethereum.VoluntaryExitOpType,
+		ValidatorPubkey: exitOp.Account.Address,
+		Epoch:           opMetadata.Epoch,
+	}, nil
+}
+
+// voluntaryExitMetadata looks up the validator index and
+// the fork a voluntary exit is signed for. The fork version
+// is the one scheduled at the exit epoch by network, or the
+// current version of the beacon node if it has no schedule.
+func voluntaryExitMetadata(
+	ctx context.Context,
+	client Client,
+	network *ethereum.NetworkConfig,
+	input *options,
+) (*exitMetadata, *types.Error) {
+	pubkey, err := ethereum.ParseValidatorAddress(input.ValidatorPubkey)
+	if err != nil {
+		return nil, wrapErr(ErrInvalidAddress, err)
+	}
+
+	index, err := client.ValidatorIndex(ctx, pubkey)
+	if errors.Is(err, ethereum.ErrValidatorNotFound) {
+		return nil, wrapErr(ErrInvalidAddress, err)
+	}
+	if err != nil {
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	fork, err := client.Fork(ctx)
+	if err != nil {
+		return nil, wrapErr(ErrBeacon, err)
+	}
+
+	metadata := &exitMetadata{
+		ValidatorIndex:        index,
+		Epoch:                 fork.Epoch,
+		ForkVersion:           encodeHex(fork.CurrentVersion[:]),
+		GenesisValidatorsRoot: encodeHex(fork.GenesisValidatorsRoot[:]),
+	}
+	if input.Epoch != nil {
+		metadata.Epoch = *input.Epoch
+	}
+	if network != nil && len(network.ForkSchedule) > 0 {
+		version := network.ForkSchedule.ExitVersionAt(metadata.Epoch)
+		metadata.ForkVersion = encodeHex(version[:])
+	}
+
+	return metadata, nil
+}
+
+// voluntaryExitPayloads returns the unsigned voluntary exit
+// and the root the validator signs for it.
+func voluntaryExitPayloads(
+	network *ethereum.NetworkConfig,
+	operations []*types.Operation,
+	metadataMap map[string]interface{},
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	exitOp, rErr := matchVoluntaryExit(operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var metadata exitMetadata
+	if err := unmarshalJSONMap(metadataMap, &metadata); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	unsignedTx := &voluntaryExit{
+		Type:            ethereum.VoluntaryExitOpType,
+		ValidatorPubkey: exitOp.Account.Address,
+		exitMetadata:    metadata,
+	}
+
+	signingRoot, err := unsignedTx.signingRoot(network)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	unsignedTxJSON, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(unsignedTxJSON),
+		Payloads: []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: unsignedTx.ValidatorPubkey},
+				Bytes:             signingRoot[:],
+				SignatureType:     ethereum.BLS12381SignatureType,
+			},
+		},
+	}, nil
+}
+
+// combineVoluntaryExit attaches the signature
+// of the validator to a voluntary exit.
+func combineVoluntaryExit(
+	network *ethereum.NetworkConfig,
+	unsignedTx string,
+	signatures []*types.Signature,
+) (*voluntaryExit, *types.Error) {
+	tx, rErr := decodeVoluntaryExit(unsignedTx, false)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	signingRoot, err := tx.signingRoot(network)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	signature, rErr := blsSignature(signatures, tx.ValidatorPubkey, signingRoot)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	tx.Signature = encodeHex(signature)
+	return tx, nil
+}
+
+// hashVoluntaryExit returns the hash tree
+// root of a signed voluntary exit.
+func hashVoluntaryExit(signedTx string) (string, *types.Error) {
+	tx, rErr := decodeVoluntaryExit(signedTx, true)
+	if rErr != nil {
+		return "", rErr
+	}
+
+	root := ethereum.VoluntaryExitRoot(tx.message())
+	return hex.EncodeToString(root[:]), nil
+}
+
+// parseVoluntaryExit returns the operation of
+// an unsigned or signed voluntary exit.
+func parseVoluntaryExit(transaction string, signed bool) (*types.ConstructionParseResponse, *types.Error) {
+	tx, rErr := decodeVoluntaryExit(transaction, signed)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	opMetadata, err := marshalJSONMap(&exitOperationMetadata{
+		ValidatorIndex: &tx.ValidatorIndex,
+		Epoch:          &tx.Epoch,
+	})
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	account := &types.AccountIdentifier{Address: tx.ValidatorPubkey}
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 0,
+			},
+			Type:     ethereum.VoluntaryExitOpType,
+			Account:  account,
+			Metadata: opMetadata,
+		},
+	}
+
+	metadata, err := marshalJSONMap(&tx.exitMetadata)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	signers := []*types.AccountIdentifier{}
+	if signed {
+		signers = append(signers, account)
+	}
+
+	return &types.ConstructionParseResponse{
+		Operations:               ops,
+		AccountIdentifierSigners: signers,
+		Metadata:                 metadata,
+	}, nil
+}
+
+// submitVoluntaryExit broadcasts a signed voluntary
+// exit and returns its hash tree root.
+func submitVoluntaryExit(ctx context.Context, client Client, signedTx string) (string, *types.Error) {
+	tx, rErr := decodeVoluntaryExit(signedTx, true)
+	if rErr != nil {
+		return "", rErr
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(tx.Signature, "0x"))
+	if err != nil {
+		return "", wrapErr(ErrSignatureInvalid, err)
+	}
+
+	root, err := client.SubmitVoluntaryExit(ctx, &pb.SignedVoluntaryExit{
+		Exit:      tx.message(),
+		Signature: signature,
+	})
+	if err != nil {
+		return "", wrapErr(submitErr(err), err)
+	}
+
+	return hex.EncodeToString(root), nil
+}
+
+// submitErr returns the error of the
+// reason a voluntary exit was rejected for.
+func submitErr(err error) *types.Error {
+	switch {
+	case errors.Is(err, ethereum.ErrValidatorAlreadyExiting):
+		return ErrValidatorAlreadyExiting
+	case errors.Is(err, ethereum.ErrExitTooEarly):
+		return ErrExitTooEarly
+	case errors.Is(err, ethereum.ErrValidatorNotActive):
+		return ErrValidatorNotActive
+	case errors.Is(err, ethereum.ErrExitSignatureInvalid):
+		return ErrSignatureInvalid
+	case errors.Is(err, ethereum.ErrValidatorNotFound):
+		return ErrInvalidAddress
+	default:
+		return ErrBroadcastFailed
+	}
+}
+
+// matchVoluntaryExit returns the operation of
+// a voluntary exit intent.
+func matchVoluntaryExit(operations []*types.Operation) (*types.Operation, *types.Error) {
+	matches, err := parser.MatchOperations(voluntaryExitDescriptions, operations)
+	if err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	exitOp, _ := matches[0].First()
+	if _, err := ethereum.ParseValidatorAddress(exitOp.Account.Address); err != nil {
+		return nil, wrapErr(ErrInvalidAddress, err)
+	}
+
+	return exitOp, nil
+}
+
+// decodeVoluntaryExit decodes the unsigned or
+// signed transaction of a voluntary exit.
+func decodeVoluntaryExit(transaction string, signed bool) (*voluntaryExit, *types.Error) {
+	var tx voluntaryExit
+	if err := json.Unmarshal([]byte(transaction), &tx); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if tx.Type != ethereum.VoluntaryExitOpType {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			fmt.Errorf("%q is not a voluntary exit", tx.Type),
+		)
+	}
+
+	if _, err := ethereum.ParseValidatorAddress(tx.ValidatorPubkey); err != nil {
+		return nil, wrapErr(ErrInvalidAddress, err)
+	}
+
+	if _, err := tx.signingRoot(nil); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if signed != (len(tx.Signature) > 0) {
+		return nil, wrapErr(
+			ErrUnableToParseIntermediateResult,
+			fmt.Errorf("transaction signed is %t but expected %t", !signed, signed),
+		)
+	}
+
+	return &tx, nil
+}
+
+// message returns the exit message signed by the validator.
+func (e *voluntaryExit) message() *pb.VoluntaryExit {
+	return &pb.VoluntaryExit{
+		Epoch:          e.Epoch,
+		ValidatorIndex: e.ValidatorIndex,
+	}
+}
+
+// signingRoot returns the root signed by the validator under
+// the DOMAIN_VOLUNTARY_EXIT domain. The domain is computed from
+// the fork schedule of network when it is known, in which case
+// the fork of the transaction must match it.
+func (e *voluntaryExit) signingRoot(network *ethereum.NetworkConfig) ([32]byte, error) {
+	var forkVersion [ethereum.ForkVersionLength]byte
+	if err := decodeFixedHex(e.ForkVersion, forkVersion[:]); err != nil {
+		return [32]byte{}, fmt.Errorf("%w: invalid fork version", err)
+	}
+
+	var genesisValidatorsRoot [32]byte
+	if err := decodeFixedHex(e.GenesisValidatorsRoot, genesisValidatorsRoot[:]); err != nil {
+		return [32]byte{}, fmt.Errorf("%w: invalid genesis validators root", err)
+	}
+
+	domain := ethereum.ComputeDomain(ethereum.DomainVoluntaryExit, forkVersion, genesisValidatorsRoot)
+	if network != nil {
+		networkDomain, err := network.Domain(ethereum.DomainVoluntaryExit, e.Epoch)
+		if err == nil && networkDomain != domain {
+			return [32]byte{}, fmt.Errorf(
+				"fork version %s and genesis validators root %s are not those of epoch %d on %s",
+				e.ForkVersion,
+				e.GenesisValidatorsRoot,
+				e.Epoch,
+				network.Name,
+			)
+		}
+	}
+
+	return ethereum.ComputeSigningRoot(ethereum.VoluntaryExitRoot(e.message()), domain), nil
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ServiceName is the service.name reported
+	// on every exported span.
+	ServiceName = "rosetta-ethereum-2.0"
+
+	// NoneExporter disables span export. Spans are still
+	// created but are discarded immediately.
+	NoneExporter = "none"
+
+	// OTLPExporter exports spans over OTLP/gRPC. The endpoint,
+	// headers and TLS settings are read from the standard
+	// OTEL_EXPORTER_OTLP_* environment variables.
+	OTLPExporter = "otlp"
+)
+
+// ShutdownFunc flushes any pending spans and
+// releases the exporter.
+type ShutdownFunc func(context.Context) error
+
+// Tracer returns a named tracer from the
+// globally registered provider.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Start registers a global tracer provider and propagator
+// for the provided exporter. The no-op provider is kept
+// when exporter is NoneExporter or empty.
+func Start(ctx context.Context, exporter string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	switch exporter {
+	case "", NoneExporter:
+		return func(context.Context) error { return nil }, nil
+	case OTLPExporter:
+	default:
+		return nil, fmt.Errorf("%s is not a valid trace exporter", exporter)
+	}
+
+	spanExporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to create otlp exporter", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(ServiceName),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to create trace resource", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
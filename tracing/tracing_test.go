@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStart(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no-op default", func(t *testing.T) {
+		shutdown, err := Start(ctx, "")
+		assert.NoError(t, err)
+		assert.NoError(t, shutdown(ctx))
+
+		_, span := Tracer("test").Start(ctx, "span")
+		assert.False(t, span.SpanContext().IsSampled())
+		span.End()
+	})
+
+	t.Run("invalid exporter", func(t *testing.T) {
+		shutdown, err := Start(ctx, "jaeger")
+		assert.Nil(t, shutdown)
+		assert.Error(t, err)
+	})
+}
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	ctx, parent := Tracer("test").Start(context.Background(), "parent")
+	_, child := Tracer("test").Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+}